@@ -0,0 +1,351 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sm-errors/entities/details"
+	"sm-errors/types"
+	"sort"
+	"sync"
+)
+
+// Ошибки каталога.
+var (
+	ErrCatalogEmptyID   = errors.New("catalog: empty error id")
+	ErrCatalogDuplicate = errors.New("catalog: duplicate error definition")
+	ErrCatalogConflict  = errors.New("catalog: conflicting error definition")
+	ErrCatalogNotFound  = errors.New("catalog: error definition not found")
+)
+
+// DefaultCatalog - глобальный каталог ошибок процесса.
+var DefaultCatalog = NewCatalog()
+
+type (
+	// Catalog - каталог ошибок, сгруппированных по идентификатору.
+	// Нулевое значение каталога готово к использованию.
+	Catalog struct {
+		definitions map[types.ID]*Definition
+		rwMux       sync.RWMutex
+	}
+
+	// Definition - описание ошибки в каталоге, не зависящее от ее транспорта.
+	Definition struct {
+		ID     types.ID
+		Type   types.ErrorType
+		Status types.Status
+
+		Message types.Message
+		Details types.Details
+		Schema  *details.Schema
+
+		StackMode types.StackMode
+
+		RestAPI   *RestAPIConstructor
+		WebSocket *WebSocketConstructor
+		Grpc      *GrpcConstructor
+	}
+
+	// Definer - описание источника определения ошибки для каталога.
+	Definer interface {
+		Definition() (d *Definition)
+	}
+)
+
+// NewCatalog - создание пустого каталога ошибок.
+func NewCatalog() (c *Catalog) {
+	c = &Catalog{
+		definitions: make(map[types.ID]*Definition),
+	}
+
+	return
+}
+
+// init - инициализация каталога, вызывается под блокировкой на запись.
+func (c *Catalog) init() {
+	if c.definitions == nil {
+		c.definitions = make(map[types.ID]*Definition)
+	}
+
+	return
+}
+
+// Register - регистрация ошибок в каталоге.
+// Определения проверяются так же, как при построении ошибки, см. Constructor.Validate:
+// сообщения по шаблону - на соответствие параметров и аргументов, схемы деталей - на корректность,
+// детали по умолчанию - на соответствие схеме.
+// Повторная регистрация идентификатора отклоняется: с ошибкой ErrCatalogDuplicate,
+// если определения совпадают, и с ошибкой ErrCatalogConflict, если различаются.
+// При ошибке ни одно из переданных определений не регистрируется.
+func (c *Catalog) Register(definers ...Definer) (err error) {
+	c.rwMux.Lock()
+	defer c.rwMux.Unlock()
+
+	var list = make(map[types.ID]*Definition, len(definers))
+
+	for _, definer := range definers {
+		var d = definer.Definition()
+
+		if d.ID == "" {
+			err = ErrCatalogEmptyID
+			return
+		}
+
+		if err = ConstructorOf[Error](d).Validate(); err != nil {
+			err = fmt.Errorf("catalog: %w", err)
+			return
+		}
+
+		var registered, ok = c.definitions[d.ID]
+
+		if !ok {
+			registered, ok = list[d.ID]
+		}
+
+		if ok {
+			if registered.equal(d) {
+				err = fmt.Errorf("%w: '%s'", ErrCatalogDuplicate, d.ID)
+			} else {
+				err = fmt.Errorf("%w: '%s'", ErrCatalogConflict, d.ID)
+			}
+
+			return
+		}
+
+		list[d.ID] = d
+	}
+
+	c.init()
+
+	for id, d := range list {
+		c.definitions[id] = d
+	}
+
+	return
+}
+
+// MustRegister - регистрация ошибок в каталоге с паникой в случае ошибки.
+func (c *Catalog) MustRegister(definers ...Definer) {
+	if err := c.Register(definers...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup - получение определения ошибки по идентификатору.
+func (c *Catalog) Lookup(id types.ID) (d *Definition, ok bool) {
+	c.rwMux.RLock()
+	defer c.rwMux.RUnlock()
+
+	if d, ok = c.definitions[id]; ok {
+		d = d.Definition()
+	}
+
+	return
+}
+
+// List - получение списка определений ошибок, отсортированного по идентификатору.
+func (c *Catalog) List() (list []*Definition) {
+	c.rwMux.RLock()
+	defer c.rwMux.RUnlock()
+
+	list = make([]*Definition, 0, len(c.definitions))
+
+	for _, d := range c.definitions {
+		list = append(list, d.Definition())
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return
+}
+
+// Register - регистрация ошибок в глобальном каталоге.
+func Register(definers ...Definer) (err error) {
+	return DefaultCatalog.Register(definers...)
+}
+
+// MustRegister - регистрация ошибок в глобальном каталоге с паникой в случае ошибки.
+func MustRegister(definers ...Definer) {
+	DefaultCatalog.MustRegister(definers...)
+}
+
+// Get - получение строителя ошибки из глобального каталога по идентификатору.
+func Get[T Error](id types.ID) (fn Builder[T], err error) {
+	return GetFrom[T](DefaultCatalog, id)
+}
+
+// MustGet - получение строителя ошибки из глобального каталога с паникой в случае отсутствия.
+func MustGet[T Error](id types.ID) (fn Builder[T]) {
+	return MustGetFrom[T](DefaultCatalog, id)
+}
+
+// GetFrom - получение строителя ошибки из каталога по идентификатору.
+// Определение проверяется перед построением строителя, ошибка проверки возвращается, см. Constructor.Validate.
+func GetFrom[T Error](c *Catalog, id types.ID) (fn Builder[T], err error) {
+	var d, ok = c.Lookup(id)
+
+	if !ok {
+		err = fmt.Errorf("%w: '%s'", ErrCatalogNotFound, id)
+		return
+	}
+
+	var cstr = ConstructorOf[T](d)
+
+	if err = cstr.Validate(); err != nil {
+		return
+	}
+
+	fn = cstr.Build()
+
+	return
+}
+
+// MustGetFrom - получение строителя ошибки из каталога с паникой в случае отсутствия или ошибки проверки.
+func MustGetFrom[T Error](c *Catalog, id types.ID) (fn Builder[T]) {
+	var err error
+
+	if fn, err = GetFrom[T](c, id); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// ConstructorOf - создание конструктора ошибки по ее определению.
+func ConstructorOf[T Error](d *Definition) (c Constructor[T]) {
+	c = Constructor[T]{
+		ID:     d.ID,
+		Type:   d.Type,
+		Status: d.Status,
+
+		Message: d.Message,
+		Details: d.Details,
+		Schema:  d.Schema,
+
+		StackMode: d.StackMode,
+	}
+
+	if d.RestAPI != nil {
+		c = c.RestAPI(*d.RestAPI)
+	}
+
+	if d.WebSocket != nil {
+		c = c.WebSocket(*d.WebSocket)
+	}
+
+	if d.Grpc != nil {
+		c = c.Grpc(*d.Grpc)
+	}
+
+	return
+}
+
+// Definition - получение определения ошибки для каталога.
+func (c Constructor[T]) Definition() (d *Definition) {
+	c.fillEmptyField()
+
+	d = &Definition{
+		ID:     c.ID,
+		Type:   c.Type,
+		Status: c.Status,
+
+		Message: c.Message,
+		Details: c.Details,
+		Schema:  c.Schema,
+
+		StackMode: c.StackMode,
+
+		RestAPI:   c.addons.RestAPI,
+		WebSocket: c.addons.WebSocket,
+		Grpc:      c.addons.Grpc,
+	}
+
+	return d.Definition()
+}
+
+// Definition - копирование определения ошибки.
+func (d *Definition) Definition() (d_ *Definition) {
+	d_ = &Definition{
+		ID:     d.ID,
+		Type:   d.Type,
+		Status: d.Status,
+
+		Schema: d.Schema.Clone(),
+
+		StackMode: d.StackMode,
+	}
+
+	if d.Message != nil {
+		d_.Message = d.Message.Clone()
+	}
+
+	if d.Details != nil {
+		d_.Details = d.Details.Clone()
+	}
+
+	if d.RestAPI != nil {
+		var cstr = *d.RestAPI
+		d_.RestAPI = &cstr
+	}
+
+	if d.WebSocket != nil {
+		var cstr = *d.WebSocket
+		d_.WebSocket = &cstr
+	}
+
+	if d.Grpc != nil {
+		var cstr = *d.Grpc
+		d_.Grpc = &cstr
+	}
+
+	return
+}
+
+// equal - сравнение определений ошибок.
+func (d *Definition) equal(o *Definition) (ok bool) {
+	if d.ID != o.ID || d.Type != o.Type || d.Status != o.Status || d.StackMode != o.StackMode {
+		return
+	}
+
+	if messageString(d.Message) != messageString(o.Message) {
+		return
+	}
+
+	if !equalPointers(d.RestAPI, o.RestAPI) || !equalPointers(d.WebSocket, o.WebSocket) || !equalPointers(d.Grpc, o.Grpc) {
+		return
+	}
+
+	if !reflect.DeepEqual(d.Schema, o.Schema) {
+		return
+	}
+
+	var (
+		dDetails, _ = json.Marshal(d.Details)
+		oDetails, _ = json.Marshal(o.Details)
+	)
+
+	ok = string(dDetails) == string(oDetails)
+
+	return
+}
+
+// messageString - получение текста сообщения с учетом его отсутствия.
+func messageString(m types.Message) (str string) {
+	if m != nil {
+		str = m.String()
+	}
+
+	return
+}
+
+// equalPointers - сравнение значений по указателям.
+func equalPointers[T comparable](a, b *T) (ok bool) {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
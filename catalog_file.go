@@ -0,0 +1,424 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+)
+
+// CatalogFileVersion - поддерживаемая версия файла определений ошибок.
+const CatalogFileVersion = 1
+
+// Типы значений деталей в файле определений ошибок, совпадают с типами схемы деталей.
+const (
+	DetailTypeString = string(details.SchemaString)
+	DetailTypeInt    = string(details.SchemaInt)
+	DetailTypeFloat  = string(details.SchemaFloat)
+	DetailTypeBool   = string(details.SchemaBool)
+	DetailTypeAny    = string(details.SchemaAny)
+)
+
+type (
+	// CatalogFile - файл определений ошибок.
+	CatalogFile struct {
+		Name string `json:"-"`
+
+		Version int                `json:"version"`
+		Errors  []CatalogFileError `json:"errors"`
+	}
+
+	// CatalogFileError - определение ошибки в файле.
+	CatalogFileError struct {
+		ID          string `json:"id"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+
+		Type    string `json:"type,omitempty"`
+		Status  string `json:"status,omitempty"`
+		Message string `json:"message"`
+
+		RestAPI   *CatalogFileTransport `json:"rest_api,omitempty"`
+		WebSocket *CatalogFileTransport `json:"web_socket,omitempty"`
+		Grpc      *CatalogFileTransport `json:"grpc,omitempty"`
+
+		Details []CatalogFileDetail `json:"details,omitempty"`
+	}
+
+	// CatalogFileTransport - настройки транспорта ошибки в файле.
+	CatalogFileTransport struct {
+		StatusCode int `json:"status_code"`
+	}
+
+	// CatalogFileDetail - описание ключа деталей ошибки в файле.
+	CatalogFileDetail struct {
+		Key         string `json:"key"`
+		Type        string `json:"type"`
+		Required    bool   `json:"required,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// LoadError - ошибка загрузки файла определений ошибок.
+	LoadError struct {
+		File   string
+		Path   string
+		Reason string
+	}
+
+	// catalogFileOrigin - место определения ошибки в файлах.
+	catalogFileOrigin struct {
+		file string
+		path string
+	}
+)
+
+// Error - получение текста ошибки загрузки.
+func (e *LoadError) Error() (s string) {
+	s = "catalog: " + e.File
+
+	if e.Path != "" {
+		s += ": " + e.Path
+	}
+
+	s += ": " + e.Reason
+
+	return
+}
+
+// ParseCatalogFile - разбор файла определений ошибок из формата JSON.
+// Неизвестные поля считаются ошибкой.
+func ParseCatalogFile(name string, data []byte) (f *CatalogFile, err error) {
+	f = &CatalogFile{
+		Name: name,
+	}
+
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err = decoder.Decode(f); err != nil {
+		var (
+			typeErr *json.UnmarshalTypeError
+			loadErr = &LoadError{
+				File:   name,
+				Reason: err.Error(),
+			}
+		)
+
+		if errors.As(err, &typeErr) {
+			loadErr.Path = typeErr.Field
+			loadErr.Reason = fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+
+		f, err = nil, loadErr
+		return
+	}
+
+	return
+}
+
+// ReadCatalogFile - чтение файла определений ошибок.
+func ReadCatalogFile(fsys fs.FS, path string) (f *CatalogFile, err error) {
+	var data []byte
+
+	if data, err = fs.ReadFile(fsys, path); err != nil {
+		err = &LoadError{
+			File:   path,
+			Reason: err.Error(),
+		}
+
+		return
+	}
+
+	return ParseCatalogFile(path, data)
+}
+
+// Definitions - проверка файла и получение определений ошибок.
+// Возвращаются все найденные ошибки проверки, объединенные через errors.Join.
+func (f *CatalogFile) Definitions() (list []*Definition, err error) {
+	var (
+		errs []error
+		ids  = make(map[string]string)
+	)
+
+	var fail = func(path, format string, a ...any) {
+		errs = append(errs, &LoadError{
+			File:   f.Name,
+			Path:   path,
+			Reason: fmt.Sprintf(format, a...),
+		})
+	}
+
+	if f.Version != CatalogFileVersion {
+		fail("version", "unsupported version %d, want %d", f.Version, CatalogFileVersion)
+	}
+
+	for i, e := range f.Errors {
+		var (
+			path = fmt.Sprintf("errors[%d]", i)
+			d    = &Definition{
+				ID: types.ID(e.ID),
+			}
+		)
+
+		// ID
+		{
+			if e.ID == "" {
+				fail(path+".id", "id is empty")
+			} else if previous, ok := ids[e.ID]; ok {
+				fail(path+".id", "duplicate id '%s', already defined at %s", e.ID, previous)
+			} else {
+				ids[e.ID] = path
+			}
+		}
+
+		// Type
+		{
+			var ok bool
+
+			if d.Type, ok = parseCatalogErrorType(e.Type); !ok {
+				fail(path+".type", "unknown error type '%s'", e.Type)
+			}
+		}
+
+		// Status
+		{
+			var ok bool
+
+			if d.Status, ok = parseCatalogStatus(e.Status); !ok {
+				fail(path+".status", "unknown status '%s'", e.Status)
+			}
+		}
+
+		// Сообщение
+		{
+			if e.Message == "" {
+				fail(path+".message", "message is empty")
+			}
+
+			// Сообщения с параметрами передаются клиентам вместе с шаблоном и аргументами.
+			if len(messages.Placeholders(e.Message)) > 0 {
+				d.Message = new(messages.TemplateMessage).Key(e.ID).Template(e.Message)
+			} else {
+				d.Message = new(messages.TextMessage).Text(e.Message)
+			}
+		}
+
+		// Транспорты
+		{
+			if e.RestAPI != nil {
+				if c := e.RestAPI.StatusCode; c < 100 || c > 599 {
+					fail(path+".rest_api.status_code", "invalid http status code %d", c)
+				}
+
+				d.RestAPI = &RestAPIConstructor{
+					StatusCode: e.RestAPI.StatusCode,
+				}
+			}
+
+			if e.WebSocket != nil {
+				if c := e.WebSocket.StatusCode; c < 1000 || c > 4999 {
+					fail(path+".web_socket.status_code", "invalid web socket close code %d", c)
+				}
+
+				d.WebSocket = &WebSocketConstructor{
+					StatusCode: e.WebSocket.StatusCode,
+				}
+			}
+
+			if e.Grpc != nil {
+				if c := e.Grpc.StatusCode; c < 0 || c > 16 {
+					fail(path+".grpc.status_code", "invalid grpc status code %d", c)
+				}
+
+				d.Grpc = &GrpcConstructor{
+					StatusCode: e.Grpc.StatusCode,
+				}
+			}
+		}
+
+		// Детали
+		{
+			var keys = make(map[string]bool)
+
+			for j, detail := range e.Details {
+				var detailPath = fmt.Sprintf("%s.details[%d]", path, j)
+
+				if detail.Key == "" {
+					fail(detailPath+".key", "key is empty")
+				} else if keys[detail.Key] {
+					fail(detailPath+".key", "duplicate key '%s'", detail.Key)
+				}
+
+				keys[detail.Key] = true
+
+				switch detail.Type {
+				case DetailTypeString, DetailTypeInt, DetailTypeFloat, DetailTypeBool, DetailTypeAny:
+				default:
+					{
+						fail(detailPath+".type", "unknown detail type '%s'", detail.Type)
+					}
+				}
+
+				if d.Schema == nil {
+					d.Schema = new(details.Schema)
+				}
+
+				d.Schema.Keys = append(d.Schema.Keys, details.SchemaKey{
+					Key:         detail.Key,
+					Type:        details.SchemaType(detail.Type),
+					Required:    detail.Required,
+					Description: detail.Description,
+				})
+			}
+		}
+
+		list = append(list, d)
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		list = nil
+	}
+
+	return
+}
+
+// LoadFiles - загрузка определений ошибок из JSON файлов в каталог.
+func (c *Catalog) LoadFiles(paths ...string) (err error) {
+	var files = make([]*CatalogFile, 0, len(paths))
+
+	for _, path := range paths {
+		var data []byte
+
+		if data, err = os.ReadFile(path); err != nil {
+			return &LoadError{
+				File:   path,
+				Reason: err.Error(),
+			}
+		}
+
+		var f *CatalogFile
+
+		if f, err = ParseCatalogFile(path, data); err != nil {
+			return
+		}
+
+		files = append(files, f)
+	}
+
+	return c.Load(files...)
+}
+
+// LoadFS - загрузка определений ошибок из JSON файлов файловой системы в каталог.
+// Файлы объединяются, пересечение идентификаторов между файлами или с уже
+// зарегистрированными ошибками считается ошибкой. При ошибке каталог не изменяется.
+func (c *Catalog) LoadFS(fsys fs.FS, paths ...string) (err error) {
+	var files = make([]*CatalogFile, 0, len(paths))
+
+	for _, path := range paths {
+		var f *CatalogFile
+
+		if f, err = ReadCatalogFile(fsys, path); err != nil {
+			return
+		}
+
+		files = append(files, f)
+	}
+
+	return c.Load(files...)
+}
+
+// Load - загрузка определений ошибок из разобранных файлов в каталог.
+func (c *Catalog) Load(files ...*CatalogFile) (err error) {
+	var (
+		errs     []error
+		origins  = make(map[types.ID]catalogFileOrigin)
+		definers = make([]Definer, 0)
+	)
+
+	for _, f := range files {
+		var list, fErr = f.Definitions()
+
+		if fErr != nil {
+			errs = append(errs, fErr)
+			continue
+		}
+
+		for i, d := range list {
+			var origin = catalogFileOrigin{
+				file: f.Name,
+				path: fmt.Sprintf("errors[%d].id", i),
+			}
+
+			if previous, ok := origins[d.ID]; ok {
+				errs = append(errs, &LoadError{
+					File:   origin.file,
+					Path:   origin.path,
+					Reason: fmt.Sprintf("duplicate id '%s', already defined in %s at %s", d.ID, previous.file, previous.path),
+				})
+
+				continue
+			}
+
+			if registered, ok := c.Lookup(d.ID); ok {
+				var reason = fmt.Sprintf("id '%s' is already registered with a different definition", d.ID)
+
+				if registered.equal(d) {
+					reason = fmt.Sprintf("id '%s' is already registered", d.ID)
+				}
+
+				errs = append(errs, &LoadError{
+					File:   origin.file,
+					Path:   origin.path,
+					Reason: reason,
+				})
+
+				continue
+			}
+
+			origins[d.ID] = origin
+			definers = append(definers, d)
+		}
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return
+	}
+
+	return c.Register(definers...)
+}
+
+// LoadFiles - загрузка определений ошибок из JSON файлов в глобальный каталог.
+func LoadFiles(paths ...string) (err error) {
+	return DefaultCatalog.LoadFiles(paths...)
+}
+
+// parseCatalogErrorType - парсинг типа ошибки с проверкой регистрации.
+// Пустое значение соответствует неизвестному типу.
+func parseCatalogErrorType(str string) (t types.ErrorType, ok bool) {
+	if str == "" {
+		return types.TypeUnknown, true
+	}
+
+	t = types.ParseErrorType(str)
+	ok = t.String() == str
+
+	return
+}
+
+// parseCatalogStatus - парсинг статуса ошибки с проверкой регистрации.
+// Пустое значение соответствует неизвестному статусу.
+func parseCatalogStatus(str string) (s types.Status, ok bool) {
+	if str == "" {
+		return types.StatusUnknown, true
+	}
+
+	s = types.ParseStatus(str)
+	ok = s.String() == str
+
+	return
+}
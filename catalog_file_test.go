@@ -0,0 +1,266 @@
+package errors
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sm-errors/entities/details"
+	"sm-errors/types"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testCatalogUsers = `{
+	"version": 1,
+	"errors": [
+		{
+			"id": "U-000001",
+			"name": "UserNotFound",
+			"type": "not_found",
+			"status": "error",
+			"message": "User not found. ",
+			"rest_api": {"status_code": 404},
+			"web_socket": {"status_code": 4404},
+			"details": [
+				{"key": "user_id", "type": "string", "required": true}
+			]
+		},
+		{
+			"id": "U-000002",
+			"type": "conflict",
+			"status": "failed",
+			"message": "User already exists. ",
+			"grpc": {"status_code": 6}
+		}
+	]
+}`
+
+const testCatalogOrders = `{
+	"version": 1,
+	"errors": [
+		{
+			"id": "O-000001",
+			"type": "system",
+			"status": "fatal",
+			"message": "Order storage is unavailable. "
+		}
+	]
+}`
+
+func TestCatalog_LoadFS(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantIDs    []types.ID
+		wantErrors []string
+	}{
+		{
+			name: "Case 1",
+			files: map[string]string{
+				"users.json":  testCatalogUsers,
+				"orders.json": testCatalogOrders,
+			},
+			wantIDs: []types.ID{"O-000001", "U-000001", "U-000002"},
+		},
+		{
+			name: "Case 2",
+			files: map[string]string{
+				"users.json":  testCatalogUsers,
+				"copy.json":   testCatalogUsers,
+				"orders.json": testCatalogOrders,
+			},
+			wantErrors: []string{
+				"catalog: copy.json: errors[0].id: duplicate id 'U-000001', already defined in users.json at errors[0].id",
+				"catalog: copy.json: errors[1].id: duplicate id 'U-000002', already defined in users.json at errors[1].id",
+			},
+		},
+		{
+			name: "Case 3",
+			files: map[string]string{
+				"broken.json": `{
+					"version": 2,
+					"errors": [
+						{"id": "", "type": "unknown_type", "status": "bad", "message": ""},
+						{"id": "B-1", "message": "Test. ", "rest_api": {"status_code": 42}, "web_socket": {"status_code": 10}, "grpc": {"status_code": 99}},
+						{"id": "B-1", "message": "Test. ", "details": [{"key": "", "type": "string"}, {"key": "k", "type": "date"}]}
+					]
+				}`,
+			},
+			wantErrors: []string{
+				"catalog: broken.json: version: unsupported version 2, want 1",
+				"catalog: broken.json: errors[0].id: id is empty",
+				"catalog: broken.json: errors[0].type: unknown error type 'unknown_type'",
+				"catalog: broken.json: errors[0].status: unknown status 'bad'",
+				"catalog: broken.json: errors[0].message: message is empty",
+				"catalog: broken.json: errors[1].rest_api.status_code: invalid http status code 42",
+				"catalog: broken.json: errors[1].web_socket.status_code: invalid web socket close code 10",
+				"catalog: broken.json: errors[1].grpc.status_code: invalid grpc status code 99",
+				"catalog: broken.json: errors[2].id: duplicate id 'B-1', already defined at errors[1]",
+				"catalog: broken.json: errors[2].details[0].key: key is empty",
+				"catalog: broken.json: errors[2].details[1].type: unknown detail type 'date'",
+			},
+		},
+		{
+			name: "Case 4",
+			files: map[string]string{
+				"typo.json": `{"version": 1, "errors": [{"id": "T-1", "mesage": "Test. "}]}`,
+			},
+			wantErrors: []string{
+				`catalog: typo.json: json: unknown field "mesage"`,
+			},
+		},
+		{
+			name: "Case 5",
+			files: map[string]string{
+				"types.json": `{"version": "1", "errors": []}`,
+			},
+			wantErrors: []string{
+				"catalog: types.json: version: expected int, got string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				c     = NewCatalog()
+				fsys  = make(fstest.MapFS)
+				paths = make([]string, 0)
+			)
+
+			for _, name := range []string{"users.json", "copy.json", "orders.json", "broken.json", "typo.json", "types.json"} {
+				if data, ok := tt.files[name]; ok {
+					fsys[name] = &fstest.MapFile{Data: []byte(data)}
+					paths = append(paths, name)
+				}
+			}
+
+			var err = c.LoadFS(fsys, paths...)
+
+			if len(tt.wantErrors) == 0 {
+				if err != nil {
+					t.Fatalf("LoadFS() error = %v", err)
+				}
+
+				var ids = make([]types.ID, 0)
+
+				for _, d := range c.List() {
+					ids = append(ids, d.ID)
+				}
+
+				if strings.Join(toStrings(ids), ",") != strings.Join(toStrings(tt.wantIDs), ",") {
+					t.Errorf("List() = %v, want %v", ids, tt.wantIDs)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("LoadFS() error = nil, want %v", tt.wantErrors)
+			}
+
+			if got := strings.Split(err.Error(), "\n"); strings.Join(got, "\n") != strings.Join(tt.wantErrors, "\n") {
+				t.Errorf("LoadFS() error =\n%v\nwant\n%v", err, strings.Join(tt.wantErrors, "\n"))
+			}
+
+			var loadErr *LoadError
+
+			if !errors.As(err, &loadErr) {
+				t.Errorf("LoadFS() error is not *LoadError")
+			}
+
+			if len(c.List()) != 0 {
+				t.Errorf("LoadFS() changed catalog on error")
+			}
+		})
+	}
+}
+
+func TestCatalog_LoadFiles(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		path = filepath.Join(dir, "users.json")
+		c    = NewCatalog()
+	)
+
+	if err := os.WriteFile(path, []byte(testCatalogUsers), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.LoadFiles(path); err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+
+	var e = MustGetFrom[RestAPI](c, "U-000001")()
+
+	if e.StatusCode() != 404 || e.Type() != types.TypeNotFound || e.Status() != types.StatusError || e.Message() != "User not found. " {
+		t.Errorf("MustGetFrom() = %v, want loaded definition", e)
+	}
+
+	if err := c.LoadFiles(path); err == nil || !strings.Contains(err.Error(), "id 'U-000001' is already registered") {
+		t.Errorf("LoadFiles() error = %v, want already registered", err)
+	}
+
+	if err := c.LoadFiles(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("LoadFiles() error = nil, want missing file error")
+	}
+}
+
+func TestCatalog_LoadFS_Schema(t *testing.T) {
+	var (
+		fsys = fstest.MapFS{
+			"users.json": {Data: []byte(testCatalogUsers)},
+		}
+		c = NewCatalog()
+	)
+
+	if err := c.LoadFS(fsys, "users.json"); err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+
+	var d, _ = c.Lookup("U-000001")
+
+	var want = &details.Schema{
+		Keys: []details.SchemaKey{
+			{Key: "user_id", Type: details.SchemaString, Required: true},
+		},
+	}
+
+	if !reflect.DeepEqual(d.Schema, want) {
+		t.Errorf("Lookup() schema = %v, want %v", d.Schema, want)
+	}
+
+	if d, _ = c.Lookup("U-000002"); d.Schema != nil {
+		t.Errorf("Lookup() schema = %v, want nil", d.Schema)
+	}
+
+	var reported []error
+
+	details.SetSchemaHandler(func(err error) {
+		reported = append(reported, err)
+	})
+
+	defer details.SetSchemaHandler(details.LogSchemaViolation)
+
+	var e = MustGetFrom[RestAPI](c, "U-000001")()
+	e.Details().Set("user_id", 42)
+
+	if _, err := e.MarshalJSON(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reported) != 1 || !errors.Is(reported[0], details.ErrSchemaViolation) {
+		t.Errorf("MarshalJSON() reported = %v, want 1 violation", reported)
+	}
+}
+
+// toStrings - преобразование идентификаторов в строки.
+func toStrings(ids []types.ID) (list []string) {
+	for _, id := range ids {
+		list = append(list, string(id))
+	}
+
+	return
+}
@@ -0,0 +1,272 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+)
+
+func TestCatalog_Register(t *testing.T) {
+	var base = Constructor[RestAPI]{
+		ID:     "T-000001",
+		Type:   types.TypeNotFound,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).
+			Text("User not found. "),
+		Details: new(details.Details).
+			Set("key", "value"),
+	}.RestAPI(
+		RestAPIConstructor{
+			StatusCode: 404,
+		})
+
+	tests := []struct {
+		name     string
+		definers []Definer
+		wantErr  error
+		wantIDs  []types.ID
+	}{
+		{
+			name:     "Case 1",
+			definers: []Definer{base},
+			wantErr:  nil,
+			wantIDs:  []types.ID{"T-000001"},
+		},
+		{
+			name: "Case 2",
+			definers: []Definer{
+				base,
+				Constructor[Error]{
+					ID:     "T-000002",
+					Type:   types.TypeSystem,
+					Status: types.StatusFatal,
+				},
+			},
+			wantErr: nil,
+			wantIDs: []types.ID{"T-000001", "T-000002"},
+		},
+		{
+			name:     "Case 3",
+			definers: []Definer{base, base},
+			wantErr:  ErrCatalogDuplicate,
+			wantIDs:  []types.ID{},
+		},
+		{
+			name: "Case 4",
+			definers: []Definer{
+				base,
+				Constructor[RestAPI]{
+					ID:     "T-000001",
+					Type:   types.TypeConflict,
+					Status: types.StatusError,
+				},
+			},
+			wantErr: ErrCatalogConflict,
+			wantIDs: []types.ID{},
+		},
+		{
+			name:     "Case 5",
+			definers: []Definer{Constructor[Error]{}},
+			wantErr:  ErrCatalogEmptyID,
+			wantIDs:  []types.ID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c = NewCatalog()
+
+			if err := c.Register(tt.definers...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var ids = make([]types.ID, 0)
+
+			for _, d := range c.List() {
+				ids = append(ids, d.ID)
+			}
+
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("List() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestCatalog_Register_Existing(t *testing.T) {
+	var (
+		c    = NewCatalog()
+		cstr = Constructor[Error]{
+			ID:     "T-000001",
+			Type:   types.TypeSystem,
+			Status: types.StatusFatal,
+
+			Message: new(messages.TextMessage).
+				Text("Example error. "),
+		}
+	)
+
+	c.MustRegister(cstr)
+
+	if err := c.Register(cstr); !errors.Is(err, ErrCatalogDuplicate) {
+		t.Errorf("Register() error = %v, wantErr %v", err, ErrCatalogDuplicate)
+	}
+
+	cstr.Message = new(messages.TextMessage).Text("Other error. ")
+
+	if err := c.Register(cstr); !errors.Is(err, ErrCatalogConflict) {
+		t.Errorf("Register() error = %v, wantErr %v", err, ErrCatalogConflict)
+	}
+}
+
+func TestCatalog_ZeroValue(t *testing.T) {
+	var c Catalog
+
+	if _, ok := c.Lookup("T-000001"); ok {
+		t.Errorf("Lookup() ok = %v, want false", ok)
+	}
+
+	if list := c.List(); len(list) != 0 {
+		t.Errorf("List() = %v, want empty", list)
+	}
+
+	c.MustRegister(Constructor[Error]{
+		ID:     "T-000001",
+		Type:   types.TypeSystem,
+		Status: types.StatusFatal,
+
+		Message: new(messages.TextMessage).
+			Text("Example error. "),
+	})
+
+	if d, ok := c.Lookup("T-000001"); !ok || d.ID != "T-000001" {
+		t.Errorf("Lookup() = %v, %v, want registered definition", d, ok)
+	}
+}
+
+func TestCatalog_Register_SchemaDefaults(t *testing.T) {
+	var (
+		c = NewCatalog()
+		d = &Definition{
+			ID:     "T-000001",
+			Type:   types.TypeValidation,
+			Status: types.StatusError,
+
+			Message: new(messages.TextMessage).Text("User is blocked. "),
+			Details: new(details.Details).Set("user_id", "abc"),
+			Schema: &details.Schema{
+				Keys: []details.SchemaKey{
+					{Key: "user_id", Type: details.SchemaInt},
+				},
+			},
+		}
+	)
+
+	if err := c.Register(d); !errors.Is(err, details.ErrSchemaViolation) {
+		t.Fatalf("Register() error = %v, want %v", err, details.ErrSchemaViolation)
+	}
+
+	if _, ok := c.Lookup(d.ID); ok {
+		t.Errorf("Lookup() found definition rejected by Register()")
+	}
+
+	// Определение, попавшее в каталог в обход проверки, не приводит к панике при получении строителя.
+	c.definitions[d.ID] = d
+
+	if _, err := GetFrom[Error](c, d.ID); !errors.Is(err, details.ErrSchemaViolation) {
+		t.Errorf("GetFrom() error = %v, want %v", err, details.ErrSchemaViolation)
+	}
+}
+
+func TestGetFrom(t *testing.T) {
+	var c = NewCatalog()
+
+	c.MustRegister(
+		Constructor[RestAPI]{
+			ID:     "T-000001",
+			Type:   types.TypeSystem,
+			Status: types.StatusFatal,
+
+			Message: new(messages.TextMessage).
+				Text("Example error. "),
+		}.RestAPI(
+			RestAPIConstructor{
+				StatusCode: 500,
+			}),
+	)
+
+	tests := []struct {
+		name    string
+		id      types.ID
+		want    RestAPI
+		wantErr error
+	}{
+		{
+			name:    "Case 1",
+			id:      "T-000001",
+			want:    ExampleRestAPIError(),
+			wantErr: nil,
+		},
+		{
+			name:    "Case 2",
+			id:      "T-000002",
+			want:    nil,
+			wantErr: ErrCatalogNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := GetFrom[RestAPI](c, tt.id)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := fn(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	var previous = DefaultCatalog
+
+	DefaultCatalog = NewCatalog()
+	defer func() {
+		DefaultCatalog = previous
+	}()
+
+	MustRegister(Constructor[Grpc]{
+		ID:     "T-000001",
+		Type:   types.TypeNotFound,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).
+			Text("Example error. "),
+	})
+
+	var e = MustGet[Grpc]("T-000001")()
+
+	if e.ID() != "T-000001" || e.Type() != types.TypeNotFound || e.StatusCode() != 5 {
+		t.Errorf("MustGet() = %v, want T-000001 not found", e)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet() did not panic for unknown id")
+		}
+	}()
+
+	MustGet[Grpc]("T-000002")
+}
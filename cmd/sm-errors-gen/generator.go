@@ -0,0 +1,573 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path/filepath"
+	errors "sm-errors"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Транспорты сгенерированных ошибок.
+const (
+	transportAuto      = "auto"
+	transportError     = "error"
+	transportRestAPI   = "rest_api"
+	transportWebSocket = "web_socket"
+	transportGrpc      = "grpc"
+)
+
+// initialisms - распространенные аббревиатуры, записываемые в верхнем регистре.
+var initialisms = map[string]bool{
+	"api": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uid": true, "url": true, "uuid": true, "xml": true,
+}
+
+// reserved - имена, занятые в сгенерированном коде.
+var reserved = map[string]bool{
+	"details": true, "e": true, "errors": true, "fmt": true, "messages": true,
+	"strings": true, "sync": true, "types": true,
+}
+
+// builtinErrorTypes - идентификаторы встроенных типов ошибок.
+var builtinErrorTypes = map[types.ErrorType]string{
+	types.TypeUnknown:        "types.TypeUnknown",
+	types.TypeSystem:         "types.TypeSystem",
+	types.TypeValidation:     "types.TypeValidation",
+	types.TypeAuthentication: "types.TypeAuthentication",
+	types.TypeAuthorization:  "types.TypeAuthorization",
+	types.TypeNotFound:       "types.TypeNotFound",
+	types.TypeConflict:       "types.TypeConflict",
+	types.TypeRateLimit:      "types.TypeRateLimit",
+	types.TypeExternal:       "types.TypeExternal",
+}
+
+// builtinStatuses - идентификаторы встроенных статусов ошибок.
+var builtinStatuses = map[types.Status]string{
+	types.StatusUnknown: "types.StatusUnknown",
+	types.StatusFailed:  "types.StatusFailed",
+	types.StatusError:   "types.StatusError",
+	types.StatusFatal:   "types.StatusFatal",
+}
+
+// detailSchemaTypes - идентификаторы типов схемы деталей.
+var detailSchemaTypes = map[string]string{
+	errors.DetailTypeString: "details.SchemaString",
+	errors.DetailTypeInt:    "details.SchemaInt",
+	errors.DetailTypeFloat:  "details.SchemaFloat",
+	errors.DetailTypeBool:   "details.SchemaBool",
+	errors.DetailTypeAny:    "details.SchemaAny",
+}
+
+// detailGoTypes - соответствие типов деталей типам Go.
+var detailGoTypes = map[string]string{
+	errors.DetailTypeString: "string",
+	errors.DetailTypeInt:    "int",
+	errors.DetailTypeFloat:  "float64",
+	errors.DetailTypeBool:   "bool",
+	errors.DetailTypeAny:    "any",
+}
+
+type (
+	// options - параметры генерации.
+	options struct {
+		Package   string
+		Out       string
+		Transport string
+	}
+
+	// genError - описание сгенерированной ошибки.
+	genError struct {
+		spec      errors.CatalogFileError
+		funcName  string
+		builder   string
+		transport string
+		args      []*genArg
+	}
+
+	// genArg - аргумент функции сгенерированной ошибки.
+	genArg struct {
+		name        string
+		key         string
+		goType      string
+		placeholder bool
+		detail      bool
+		required    bool
+	}
+)
+
+// generate - генерация исходного кода по файлам каталога.
+func generate(opts *options, files ...*errors.CatalogFile) (src []byte, err error) {
+	if !token.IsIdentifier(opts.Package) {
+		err = fmt.Errorf("invalid package name '%s'", opts.Package)
+		return
+	}
+
+	if err = errors.NewCatalog().Load(files...); err != nil {
+		return
+	}
+
+	var (
+		list  = make([]*genError, 0)
+		names = make(map[string]types.ID)
+	)
+
+	for _, f := range files {
+		for _, spec := range f.Errors {
+			var e *genError
+
+			if e, err = newGenError(spec, opts.Transport); err != nil {
+				return
+			}
+
+			if id, ok := names[e.funcName]; ok {
+				err = fmt.Errorf("function name '%s' of error '%s' is already used by error '%s'", e.funcName, spec.ID, id)
+				return
+			}
+
+			names[e.funcName] = types.ID(spec.ID)
+			list = append(list, e)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].funcName < list[j].funcName
+	})
+
+	var buf = new(bytes.Buffer)
+
+	writeHeader(buf, opts, files, list)
+
+	for _, e := range list {
+		e.write(buf)
+	}
+
+	if src, err = format.Source(buf.Bytes()); err != nil {
+		err = fmt.Errorf("format generated source: %w", err)
+		return
+	}
+
+	return
+}
+
+// newGenError - подготовка описания сгенерированной ошибки.
+func newGenError(spec errors.CatalogFileError, transport string) (e *genError, err error) {
+	e = &genError{
+		spec: spec,
+	}
+
+	// Имя функции
+	{
+		var name = spec.Name
+
+		if name == "" {
+			name = exportedName(spec.ID)
+		}
+
+		e.funcName = "Err" + name
+
+		if !token.IsIdentifier(e.funcName) || !token.IsExported(e.funcName) {
+			err = fmt.Errorf("invalid function name '%s' for error '%s'", e.funcName, spec.ID)
+			return
+		}
+
+		e.builder = "builder" + e.funcName
+	}
+
+	// Транспорт
+	{
+		if e.transport, err = resolveTransport(spec, transport); err != nil {
+			return
+		}
+	}
+
+	// Аргументы
+	{
+		var byKey = make(map[string]*genArg)
+
+		for _, name := range messages.Placeholders(spec.Message) {
+			var arg = &genArg{
+				key:         name,
+				goType:      "string",
+				placeholder: true,
+			}
+
+			byKey[arg.key] = arg
+			e.args = append(e.args, arg)
+		}
+
+		for _, detail := range spec.Details {
+			var arg, ok = byKey[detail.Key]
+
+			if !ok {
+				arg = &genArg{
+					key: detail.Key,
+				}
+
+				byKey[arg.key] = arg
+				e.args = append(e.args, arg)
+			}
+
+			arg.goType = detailGoTypes[detail.Type]
+			arg.detail = true
+			arg.required = detail.Required
+		}
+
+		var used = make(map[string]bool)
+
+		for _, arg := range e.args {
+			arg.name = argName(arg.key)
+
+			for used[arg.name] {
+				arg.name += "_"
+			}
+
+			used[arg.name] = true
+		}
+	}
+
+	return
+}
+
+// resolveTransport - определение транспорта ошибки.
+func resolveTransport(spec errors.CatalogFileError, transport string) (t string, err error) {
+	switch transport {
+	case transportError, transportRestAPI, transportWebSocket, transportGrpc:
+		{
+			t = transport
+		}
+	case transportAuto, "":
+		{
+			switch {
+			case spec.RestAPI != nil:
+				t = transportRestAPI
+			case spec.WebSocket != nil:
+				t = transportWebSocket
+			case spec.Grpc != nil:
+				t = transportGrpc
+			default:
+				t = transportError
+			}
+		}
+	default:
+		{
+			err = fmt.Errorf("unknown transport '%s'", transport)
+		}
+	}
+
+	return
+}
+
+// iface - получение имени интерфейса ошибки для транспорта.
+func (e *genError) iface() (name string) {
+	switch e.transport {
+	case transportRestAPI:
+		return "RestAPI"
+	case transportWebSocket:
+		return "WebSocket"
+	case transportGrpc:
+		return "Grpc"
+	}
+
+	return "Error"
+}
+
+// write - запись исходного кода ошибки.
+func (e *genError) write(buf *bytes.Buffer) {
+	var (
+		iface = e.iface()
+		spec  = e.spec
+	)
+
+	// Строитель
+	{
+		fmt.Fprintf(buf, "// %s - строитель ошибки %s.\n", e.builder, spec.ID)
+		fmt.Fprintf(buf, "var %s = sync.OnceValue(func() errors.Builder[errors.%s] {\n", e.builder, iface)
+		fmt.Fprintf(buf, "return errors.Constructor[errors.%s]{\n", iface)
+		fmt.Fprintf(buf, "ID: %q,\n", spec.ID)
+		fmt.Fprintf(buf, "Type: %s,\n", errorTypeExpr(spec.Type))
+		fmt.Fprintf(buf, "Status: %s,\n\n", statusExpr(spec.Status))
+		if e.hasPlaceholders() {
+			fmt.Fprintf(buf, "Message: new(messages.TemplateMessage).\nKey(%q).\nTemplate(%q),\n", spec.ID, spec.Message)
+		} else {
+			fmt.Fprintf(buf, "Message: new(messages.TextMessage).\nText(%q),\n", spec.Message)
+		}
+		e.writeSchema(buf)
+		buf.WriteString("}")
+
+		var addons = []struct {
+			method    string
+			transport *errors.CatalogFileTransport
+		}{
+			{"RestAPI", spec.RestAPI},
+			{"WebSocket", spec.WebSocket},
+			{"Grpc", spec.Grpc},
+		}
+
+		for _, addon := range addons {
+			if addon.transport == nil {
+				continue
+			}
+
+			fmt.Fprintf(buf, ".%s(\nerrors.%sConstructor{\nStatusCode: %d,\n})", addon.method, addon.method, addon.transport.StatusCode)
+		}
+
+		buf.WriteString(".Build()\n})\n\n")
+	}
+
+	// Функция
+	{
+		var doc = strings.TrimSpace(spec.Description)
+
+		if doc == "" {
+			doc = strings.TrimSpace(spec.Message)
+		}
+
+		for i, line := range strings.Split(doc, "\n") {
+			if i == 0 {
+				fmt.Fprintf(buf, "// %s - %s\n", e.funcName, strings.TrimSpace(line))
+				continue
+			}
+
+			fmt.Fprintf(buf, "// %s\n", strings.TrimSpace(line))
+		}
+
+		fmt.Fprintf(buf, "// Идентификатор ошибки: %s.\n", spec.ID)
+
+		var params = make([]string, 0, len(e.args))
+
+		for _, arg := range e.args {
+			params = append(params, arg.name+" "+arg.goType)
+		}
+
+		fmt.Fprintf(buf, "func %s(%s) (e errors.%s) {\n", e.funcName, strings.Join(params, ", "), iface)
+		fmt.Fprintf(buf, "e = %s()()\n", e.builder)
+
+		if e.hasPlaceholders() {
+			buf.WriteString("\ne.SetMessageArgs(map[string]any{\n")
+
+			for _, arg := range e.args {
+				if arg.placeholder {
+					fmt.Fprintf(buf, "%q: %s,\n", arg.key, arg.name)
+				}
+			}
+
+			buf.WriteString("})\n")
+		}
+
+		for _, arg := range e.args {
+			if !arg.detail {
+				continue
+			}
+
+			if arg.required {
+				fmt.Fprintf(buf, "\ne.Details().Set(%q, %s)\n", arg.key, arg.name)
+				continue
+			}
+
+			fmt.Fprintf(buf, "\nif %s != %s {\ne.Details().Set(%q, %s)\n}\n", arg.name, arg.zero(), arg.key, arg.name)
+		}
+
+		buf.WriteString("\nreturn\n}\n\n")
+	}
+}
+
+// writeSchema - запись схемы деталей ошибки по описанию ключей деталей в файле.
+func (e *genError) writeSchema(buf *bytes.Buffer) {
+	if len(e.spec.Details) == 0 {
+		return
+	}
+
+	buf.WriteString("\nSchema: &details.Schema{\nKeys: []details.SchemaKey{\n")
+
+	for _, detail := range e.spec.Details {
+		fmt.Fprintf(buf, "{Key: %q, Type: %s", detail.Key, detailSchemaTypes[detail.Type])
+
+		if detail.Required {
+			buf.WriteString(", Required: true")
+		}
+
+		if detail.Description != "" {
+			fmt.Fprintf(buf, ", Description: %q", detail.Description)
+		}
+
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("},\n},\n")
+
+	return
+}
+
+// hasPlaceholders - проверка наличия параметров в сообщении ошибки.
+func (e *genError) hasPlaceholders() (ok bool) {
+	for _, arg := range e.args {
+		if arg.placeholder {
+			return true
+		}
+	}
+
+	return
+}
+
+// zero - получение нулевого значения типа аргумента.
+func (a *genArg) zero() (expr string) {
+	switch a.goType {
+	case "string":
+		return `""`
+	case "int", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	}
+
+	return "nil"
+}
+
+// writeHeader - запись заголовка сгенерированного файла.
+// Импортируются только пакеты, используемые кодом ошибок: пакет деталей - при наличии схемы деталей.
+func writeHeader(buf *bytes.Buffer, opts *options, files []*errors.CatalogFile, list []*genError) {
+	var sources = make([]string, 0, len(files))
+
+	for _, f := range files {
+		sources = append(sources, filepath.Base(f.Name))
+	}
+
+	buf.WriteString("// Code generated by sm-errors-gen. DO NOT EDIT.\n")
+	fmt.Fprintf(buf, "// Источники: %s.\n\n", strings.Join(sources, ", "))
+	fmt.Fprintf(buf, "package %s\n\n", opts.Package)
+
+	if len(list) == 0 {
+		return
+	}
+
+	var schema bool
+
+	for _, e := range list {
+		schema = schema || len(e.spec.Details) > 0
+	}
+
+	buf.WriteString("import (\n")
+
+	buf.WriteString("\"sync\"\n\n")
+	buf.WriteString("errors \"sm-errors\"\n")
+
+	if schema {
+		buf.WriteString("\"sm-errors/entities/details\"\n")
+	}
+
+	buf.WriteString("\"sm-errors/entities/messages\"\n")
+	buf.WriteString("\"sm-errors/types\"\n")
+	buf.WriteString(")\n\n")
+
+	return
+}
+
+// errorTypeExpr - получение выражения для типа ошибки.
+func errorTypeExpr(name string) (expr string) {
+	if name == "" {
+		return builtinErrorTypes[types.TypeUnknown]
+	}
+
+	if expr, ok := builtinErrorTypes[types.ParseErrorType(name)]; ok && types.ParseErrorType(name).String() == name {
+		return expr
+	}
+
+	return fmt.Sprintf("types.ParseErrorType(%q)", name)
+}
+
+// statusExpr - получение выражения для статуса ошибки.
+func statusExpr(name string) (expr string) {
+	if name == "" {
+		return builtinStatuses[types.StatusUnknown]
+	}
+
+	if expr, ok := builtinStatuses[types.ParseStatus(name)]; ok && types.ParseStatus(name).String() == name {
+		return expr
+	}
+
+	return fmt.Sprintf("types.ParseStatus(%q)", name)
+}
+
+// exportedName - получение экспортируемого имени из произвольной строки.
+func exportedName(str string) (name string) {
+	for _, part := range splitWords(str) {
+		name += capitalize(part)
+	}
+
+	return
+}
+
+// argName - получение имени аргумента из ключа.
+func argName(key string) (name string) {
+	var parts = splitWords(key)
+
+	for i, part := range parts {
+		if i == 0 {
+			if initialisms[strings.ToLower(part)] {
+				name += strings.ToLower(part)
+			} else {
+				name += lowerFirst(part)
+			}
+
+			continue
+		}
+
+		name += capitalize(part)
+	}
+
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "arg" + capitalize(name)
+	}
+
+	if token.IsKeyword(name) || reserved[name] {
+		name += "_"
+	}
+
+	return
+}
+
+// splitWords - разделение строки на слова по небуквенно-цифровым символам.
+func splitWords(str string) (words []string) {
+	return strings.FieldsFunc(str, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// capitalize - преобразование слова к виду с заглавной буквы с учетом аббревиатур.
+func capitalize(word string) (str string) {
+	if initialisms[strings.ToLower(word)] {
+		return strings.ToUpper(word)
+	}
+
+	return upperFirst(word)
+}
+
+// upperFirst - преобразование первой буквы строки к верхнему регистру.
+func upperFirst(str string) (s string) {
+	var r, size = utf8.DecodeRuneInString(str)
+
+	if size == 0 {
+		return
+	}
+
+	return string(unicode.ToUpper(r)) + str[size:]
+}
+
+// lowerFirst - преобразование первой буквы строки к нижнему регистру.
+func lowerFirst(str string) (s string) {
+	var r, size = utf8.DecodeRuneInString(str)
+
+	if size == 0 {
+		return
+	}
+
+	return string(unicode.ToLower(r)) + str[size:]
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	errors "sm-errors"
+	"strings"
+	"testing"
+)
+
+const testCatalog = `{
+	"version": 1,
+	"errors": [
+		{
+			"id": "U-000001",
+			"name": "UserNotFound",
+			"description": "Пользователь не найден.",
+			"type": "not_found",
+			"status": "error",
+			"message": "User {user_id} not found",
+			"rest_api": {"status_code": 404},
+			"details": [
+				{"key": "user_id", "type": "string", "required": true},
+				{"key": "attempt", "type": "int", "description": "Номер попытки."}
+			]
+		},
+		{
+			"id": "S-000001",
+			"type": "system",
+			"status": "fatal",
+			"message": "Storage is unavailable."
+		}
+	]
+}`
+
+const testGenerated = `// Code generated by sm-errors-gen. DO NOT EDIT.
+// Источники: users.json.
+
+package apperrors
+
+import (
+	"sync"
+
+	errors "sm-errors"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+)
+
+// builderErrS000001 - строитель ошибки S-000001.
+var builderErrS000001 = sync.OnceValue(func() errors.Builder[errors.Error] {
+	return errors.Constructor[errors.Error]{
+		ID:     "S-000001",
+		Type:   types.TypeSystem,
+		Status: types.StatusFatal,
+
+		Message: new(messages.TextMessage).
+			Text("Storage is unavailable."),
+	}.Build()
+})
+
+// ErrS000001 - Storage is unavailable.
+// Идентификатор ошибки: S-000001.
+func ErrS000001() (e errors.Error) {
+	e = builderErrS000001()()
+
+	return
+}
+
+// builderErrUserNotFound - строитель ошибки U-000001.
+var builderErrUserNotFound = sync.OnceValue(func() errors.Builder[errors.RestAPI] {
+	return errors.Constructor[errors.RestAPI]{
+		ID:     "U-000001",
+		Type:   types.TypeNotFound,
+		Status: types.StatusError,
+
+		Message: new(messages.TemplateMessage).
+			Key("U-000001").
+			Template("User {user_id} not found"),
+
+		Schema: &details.Schema{
+			Keys: []details.SchemaKey{
+				{Key: "user_id", Type: details.SchemaString, Required: true},
+				{Key: "attempt", Type: details.SchemaInt, Description: "Номер попытки."},
+			},
+		},
+	}.RestAPI(
+		errors.RestAPIConstructor{
+			StatusCode: 404,
+		}).Build()
+})
+
+// ErrUserNotFound - Пользователь не найден.
+// Идентификатор ошибки: U-000001.
+func ErrUserNotFound(userID string, attempt int) (e errors.RestAPI) {
+	e = builderErrUserNotFound()()
+
+	e.SetMessageArgs(map[string]any{
+		"user_id": userID,
+	})
+
+	e.Details().Set("user_id", userID)
+
+	if attempt != 0 {
+		e.Details().Set("attempt", attempt)
+	}
+
+	return
+}
+`
+
+func TestGenerate(t *testing.T) {
+	var f, err = errors.ParseCatalogFile("testdata/users.json", []byte(testCatalog))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var opts = &options{
+		Package:   "apperrors",
+		Transport: transportAuto,
+	}
+
+	for n := 0; n < 2; n++ {
+		var src []byte
+
+		if src, err = generate(opts, f); err != nil {
+			t.Fatalf("generate() error = %v", err)
+		}
+
+		if string(src) != testGenerated {
+			t.Fatalf("generate() =\n%s\nwant\n%s", src, testGenerated)
+		}
+
+		if _, err = parser.ParseFile(token.NewFileSet(), "errors_gen.go", src, parser.AllErrors); err != nil {
+			t.Errorf("generate() produced invalid source: %v", err)
+		}
+	}
+}
+
+func TestGenerate_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+	}{
+		{
+			name:    "Case 1",
+			catalog: `{"version": 1, "errors": []}`,
+		},
+		{
+			name:    "Case 2",
+			catalog: `{"version": 1, "errors": [{"id": "A-1", "message": "Storage is unavailable."}]}`,
+		},
+		{
+			name: "Case 3",
+			catalog: `{"version": 1, "errors": [{"id": "ошибка-1", "message": "Пользователь {имя} не найден", "details": [
+				{"key": "имя", "type": "string", "required": true},
+				{"key": "ёмкость", "type": "int"}
+			]}]}`,
+		},
+		{
+			name:    "Case 4",
+			catalog: testCatalog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f, err = errors.ParseCatalogFile("users.json", []byte(tt.catalog))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var src []byte
+
+			if src, err = generate(&options{Package: "apperrors"}, f); err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+
+			var (
+				fset    = token.NewFileSet()
+				file, _ = parser.ParseFile(fset, "errors_gen.go", src, parser.AllErrors)
+				config  = &gotypes.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+			)
+
+			if _, err = config.Check("apperrors", fset, []*ast.File{file}, nil); err != nil {
+				t.Errorf("generate() produced source that does not compile: %v\n%s", err, src)
+			}
+		})
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    options
+		catalog string
+		wantErr string
+	}{
+		{
+			name: "Case 1",
+			opts: options{
+				Package: "1apperrors",
+			},
+			catalog: testCatalog,
+			wantErr: "invalid package name '1apperrors'",
+		},
+		{
+			name: "Case 2",
+			opts: options{
+				Package:   "apperrors",
+				Transport: "smtp",
+			},
+			catalog: testCatalog,
+			wantErr: "unknown transport 'smtp'",
+		},
+		{
+			name: "Case 3",
+			opts: options{
+				Package: "apperrors",
+			},
+			catalog: `{"version": 1, "errors": [
+				{"id": "A-1", "name": "Same", "message": "A."},
+				{"id": "A-2", "name": "Same", "message": "B."}
+			]}`,
+			wantErr: "function name 'ErrSame' of error 'A-2' is already used by error 'A-1'",
+		},
+		{
+			name: "Case 4",
+			opts: options{
+				Package: "apperrors",
+			},
+			catalog: `{"version": 1, "errors": [{"id": "A-1", "message": ""}]}`,
+			wantErr: "catalog: users.json: errors[0].message: message is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f, err = errors.ParseCatalogFile("users.json", []byte(tt.catalog))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = generate(&tt.opts, f); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("generate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_argName(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{
+			name: "Case 1",
+			key:  "user_id",
+			want: "userID",
+		},
+		{
+			name: "Case 2",
+			key:  "id",
+			want: "id",
+		},
+		{
+			name: "Case 3",
+			key:  "type",
+			want: "type_",
+		},
+		{
+			name: "Case 4",
+			key:  "errors",
+			want: "errors_",
+		},
+		{
+			name: "Case 5",
+			key:  "1st",
+			want: "arg1st",
+		},
+		{
+			name: "Case 6",
+			key:  "request-url",
+			want: "requestURL",
+		},
+		{
+			name: "Case 7",
+			key:  "Имя_пользователя",
+			want: "имяПользователя",
+		},
+		{
+			name: "Case 8",
+			key:  "-",
+			want: "arg",
+		},
+		{
+			name: "Case 9",
+			key:  "details",
+			want: "details_",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := argName(tt.key); got != tt.want {
+				t.Errorf("argName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
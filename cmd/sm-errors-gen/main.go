@@ -0,0 +1,30 @@
+// Команда sm-errors-gen генерирует типизированные конструкторы ошибок по JSON каталогу.
+//
+// Использование через go generate:
+//
+//	//go:generate go run sm-errors/cmd/sm-errors-gen -package apperrors -out errors_gen.go errors.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var opts = new(options)
+
+	flag.StringVar(&opts.Package, "package", "", "имя пакета сгенерированного файла, по умолчанию $GOPACKAGE")
+	flag.StringVar(&opts.Out, "out", "", "путь к сгенерированному файлу, по умолчанию стандартный вывод")
+	flag.StringVar(&opts.Transport, "transport", transportAuto, "транспорт ошибок: auto, error, rest_api, web_socket, grpc")
+	flag.Parse()
+
+	if opts.Package == "" {
+		opts.Package = os.Getenv("GOPACKAGE")
+	}
+
+	if err := run(opts, flag.Args()); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "sm-errors-gen:", err)
+		os.Exit(1)
+	}
+}
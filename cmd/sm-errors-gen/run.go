@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	errors "sm-errors"
+)
+
+// run - чтение файлов каталога, генерация и запись результата.
+func run(opts *options, paths []string) (err error) {
+	if len(paths) == 0 {
+		err = fmt.Errorf("no catalog files")
+		return
+	}
+
+	var files = make([]*errors.CatalogFile, 0, len(paths))
+
+	for _, path := range paths {
+		var data []byte
+
+		if data, err = os.ReadFile(path); err != nil {
+			return
+		}
+
+		var f *errors.CatalogFile
+
+		if f, err = errors.ParseCatalogFile(path, data); err != nil {
+			return
+		}
+
+		files = append(files, f)
+	}
+
+	var src []byte
+
+	if src, err = generate(opts, files...); err != nil {
+		return
+	}
+
+	if opts.Out == "" {
+		_, err = os.Stdout.Write(src)
+		return
+	}
+
+	return os.WriteFile(opts.Out, src, 0o644)
+}
@@ -0,0 +1,24 @@
+// Команда sm-errors-po выгружает сообщения каталога ошибок в файлы переводов gettext
+// и загружает переведенные файлы обратно в набор переводов.
+//
+// Выгрузка шаблона и файла перевода с сохранением существующих переводов:
+//
+//	sm-errors-po extract -out errors.pot errors.json
+//	sm-errors-po extract -locale ru -out ru.po errors.json
+//
+// Загрузка переводов в JSON файл набора переводов (см. messages.Bundle):
+//
+//	sm-errors-po import -catalog errors.json -out translations.json ru.po kk.po
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "sm-errors-po:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	errors "sm-errors"
+	"sm-errors/entities/messages"
+	"strings"
+)
+
+// Подкоманды.
+const (
+	commandExtract = "extract"
+	commandImport  = "import"
+)
+
+// errIncomplete - ошибка строгого режима при наличии непереведенных или устаревших записей.
+var errIncomplete = fmt.Errorf("translations are incomplete")
+
+// paths - список путей из повторяющегося флага.
+type paths []string
+
+// String - получение строкового представления списка путей.
+func (p *paths) String() (str string) {
+	return strings.Join(*p, ",")
+}
+
+// Set - добавить путь в список.
+func (p *paths) Set(value string) (err error) {
+	*p = append(*p, value)
+	return
+}
+
+// run - разбор аргументов и выполнение подкоманды.
+func run(args []string) (err error) {
+	if len(args) == 0 {
+		err = fmt.Errorf("expected '%s' or '%s' command", commandExtract, commandImport)
+		return
+	}
+
+	switch args[0] {
+	case commandExtract:
+		return runExtract(args[1:], os.Stdout, os.Stderr)
+	case commandImport:
+		return runImport(args[1:], os.Stderr)
+	}
+
+	return fmt.Errorf("unknown command '%s'", args[0])
+}
+
+// runExtract - выгрузка сообщений каталога в файл переводов.
+func runExtract(args []string, stdout, stderr io.Writer) (err error) {
+	var (
+		fs     = flag.NewFlagSet(commandExtract, flag.ContinueOnError)
+		locale = fs.String("locale", "", "язык файла перевода, пустое значение - шаблон .pot")
+		out    = fs.String("out", "", "путь к файлу переводов, существующие переводы сохраняются; по умолчанию стандартный вывод")
+		strict = fs.Bool("strict", false, "завершаться с ошибкой при наличии непереведенных или устаревших записей")
+	)
+
+	fs.SetOutput(stderr)
+
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+
+	var catalog *errors.Catalog
+
+	if catalog, err = loadCatalog(fs.Args()); err != nil {
+		return
+	}
+
+	var existing *messages.POFile
+
+	if *out != "" && *locale != "" {
+		if existing, err = readPO(*out); err != nil && !os.IsNotExist(err) {
+			return
+		}
+
+		err = nil
+	}
+
+	var f, report = catalog.ExportPO(*locale, existing)
+
+	if *out == "" {
+		_, err = f.WriteTo(stdout)
+	} else {
+		err = os.WriteFile(*out, f.Bytes(), 0o644)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if *locale != "" {
+		_, _ = fmt.Fprintln(stderr, report)
+	}
+
+	return checkReports(*strict, report)
+}
+
+// runImport - загрузка переведенных файлов в JSON файл набора переводов.
+func runImport(args []string, stderr io.Writer) (err error) {
+	var (
+		fs            = flag.NewFlagSet(commandImport, flag.ContinueOnError)
+		catalogs      paths
+		out           = fs.String("out", "", "путь к JSON файлу набора переводов, существующие переводы сохраняются")
+		defaultLocale = fs.String("default", "en", "язык по умолчанию нового набора переводов")
+		strict        = fs.Bool("strict", false, "завершаться с ошибкой при наличии непереведенных или устаревших записей")
+	)
+
+	fs.Var(&catalogs, "catalog", "путь к JSON файлу каталога ошибок, флаг можно повторять")
+	fs.SetOutput(stderr)
+
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+
+	if *out == "" {
+		err = fmt.Errorf("-out is required")
+		return
+	}
+
+	if len(fs.Args()) == 0 {
+		err = fmt.Errorf("no po files")
+		return
+	}
+
+	var catalog *errors.Catalog
+
+	if catalog, err = loadCatalog(catalogs); err != nil {
+		return
+	}
+
+	var bundle = messages.NewBundle(*defaultLocale)
+
+	if data, rErr := os.ReadFile(*out); rErr == nil {
+		if err = json.Unmarshal(data, bundle); err != nil {
+			return fmt.Errorf("%s: %w", *out, err)
+		}
+	} else if !os.IsNotExist(rErr) {
+		return rErr
+	}
+
+	var reports = make([]*errors.TranslationReport, 0, len(fs.Args()))
+
+	for _, path := range fs.Args() {
+		var f *messages.POFile
+
+		if f, err = readPO(path); err != nil {
+			return
+		}
+
+		var report *errors.TranslationReport
+
+		if report, err = catalog.ImportPO(bundle, f); err != nil {
+			return
+		}
+
+		_, _ = fmt.Fprintln(stderr, report)
+		reports = append(reports, report)
+	}
+
+	var data []byte
+
+	if data, err = json.MarshalIndent(bundle, "", "\t"); err != nil {
+		return
+	}
+
+	if err = os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		return
+	}
+
+	return checkReports(*strict, reports...)
+}
+
+// loadCatalog - загрузка каталога ошибок из JSON файлов.
+func loadCatalog(list []string) (c *errors.Catalog, err error) {
+	if len(list) == 0 {
+		err = fmt.Errorf("no catalog files")
+		return
+	}
+
+	c = errors.NewCatalog()
+
+	if err = c.LoadFiles(list...); err != nil {
+		c = nil
+	}
+
+	return
+}
+
+// readPO - чтение файла переводов.
+func readPO(path string) (f *messages.POFile, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return
+	}
+
+	return messages.ParsePO(path, bytes.NewReader(data))
+}
+
+// checkReports - проверка отчетов в строгом режиме.
+func checkReports(strict bool, reports ...*errors.TranslationReport) (err error) {
+	if !strict {
+		return
+	}
+
+	for _, r := range reports {
+		if len(r.Untranslated) > 0 || len(r.Stale) > 0 {
+			return errIncomplete
+		}
+	}
+
+	return
+}
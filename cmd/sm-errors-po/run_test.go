@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sm-errors/entities/messages"
+	"strings"
+	"testing"
+)
+
+const testCatalog = `{
+	"version": 1,
+	"errors": [
+		{"id": "U-000001", "type": "not_found", "status": "error", "message": "User {user_id} not found"},
+		{"id": "S-000001", "type": "system", "status": "fatal", "message": "Storage is unavailable."}
+	]
+}`
+
+func TestRun(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		catalog = filepath.Join(dir, "errors.json")
+		po      = filepath.Join(dir, "ru.po")
+		out     = filepath.Join(dir, "translations.json")
+		stdout  = new(bytes.Buffer)
+		stderr  = new(bytes.Buffer)
+	)
+
+	if err := os.WriteFile(catalog, []byte(testCatalog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Шаблон
+	{
+		if err := runExtract([]string{catalog}, stdout, stderr); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(stdout.String(), "msgctxt \"U-000001\"\nmsgid \"User {user_id} not found\"\nmsgstr \"\"\n") {
+			t.Errorf("extract output =\n%s", stdout)
+		}
+	}
+
+	// Перевод
+	{
+		if err := runExtract([]string{"-locale", "ru", "-out", po, "-strict", catalog}, stdout, stderr); err != errIncomplete {
+			t.Fatalf("extract -strict error = %v, want %v", err, errIncomplete)
+		}
+
+		var data, err = os.ReadFile(po)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data = []byte(strings.Replace(string(data),
+			"msgid \"User {user_id} not found\"\nmsgstr \"\"",
+			"msgid \"User {user_id} not found\"\nmsgstr \"Пользователь {user_id} не найден\"", 1))
+
+		if err = os.WriteFile(po, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Загрузка
+	{
+		stderr.Reset()
+
+		if err := runImport([]string{"-catalog", catalog, "-out", out, po}, stderr); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "ru: 1 untranslated, 0 stale\n  untranslated S-000001: \"Storage is unavailable.\"\n"; stderr.String() != want {
+			t.Errorf("import report = %q, want %q", stderr, want)
+		}
+
+		var data, err = os.ReadFile(out)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b = new(messages.Bundle)
+
+		if err = json.Unmarshal(data, b); err != nil {
+			t.Fatal(err)
+		}
+
+		if text, _, _ := b.Text("U-000001", "ru"); text != "Пользователь {user_id} не найден" {
+			t.Errorf("Text() = %v", text)
+		}
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "Case 1",
+			args:    nil,
+			wantErr: "expected 'extract' or 'import' command",
+		},
+		{
+			name:    "Case 2",
+			args:    []string{"merge"},
+			wantErr: "unknown command 'merge'",
+		},
+		{
+			name:    "Case 3",
+			args:    []string{"extract"},
+			wantErr: "no catalog files",
+		},
+		{
+			name:    "Case 4",
+			args:    []string{"import", "ru.po"},
+			wantErr: "-out is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.args); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("run() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"fmt"
 	"reflect"
 	"sm-errors/entities/details"
 	"sm-errors/entities/messages"
@@ -26,6 +27,12 @@ type (
 		Message types.Message
 		Details types.Details
 
+		// Schema - схема деталей ошибки: ключи, типы значений и обязательность.
+		// Детали проверяются при построении строителя и при упаковке ошибки, см. details.SetSchemaHandler.
+		Schema *details.Schema
+
+		StackMode types.StackMode
+
 		addons *constructorAddons
 	}
 
@@ -33,6 +40,7 @@ type (
 	constructorAddons struct {
 		RestAPI   *RestAPIConstructor
 		WebSocket *WebSocketConstructor
+		Grpc      *GrpcConstructor
 	}
 
 	// RestAPIConstructor - конструктор для построения ошибок rest api.
@@ -44,12 +52,22 @@ type (
 	WebSocketConstructor struct {
 		StatusCode int
 	}
+
+	// GrpcConstructor - конструктор для построения ошибок grpc.
+	GrpcConstructor struct {
+		StatusCode int
+	}
 )
 
 // Build - построение ошибки.
+// Если конструктор не проходит проверку Validate, вызывается паника.
 func (c Constructor[T]) Build() (fn Builder[T]) {
 	c.fillEmptyField()
 
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+
 	var store = &internal.Store{
 		ID:     c.ID,
 		Type:   c.Type,
@@ -58,6 +76,9 @@ func (c Constructor[T]) Build() (fn Builder[T]) {
 		Err:     c.Err,
 		Message: c.Message.Clone(),
 		Details: c.Details.Clone(),
+		Schema:  c.Schema.Clone(),
+
+		StackMode: c.StackMode,
 
 		Others: new(internal.StoreOthers),
 	}
@@ -75,9 +96,20 @@ func (c Constructor[T]) Build() (fn Builder[T]) {
 				StatusCode: c.addons.WebSocket.StatusCode,
 			}
 		}
+
+		if c.addons.Grpc != nil {
+			store.Others.Grpc = &internal.GrpcStore{
+				StatusCode: c.addons.Grpc.StatusCode,
+			}
+		}
 	}
 
+	// Каждый вызов строителя получает собственную копию хранилища,
+	// поэтому изменения одной ошибки не затрагивают другие.
 	fn = func() (e T) {
+		var store = store.Clone()
+		store.CaptureStack(1)
+
 		switch reflect.TypeOf(new(T)).String() {
 		case "*errors.Error":
 			{
@@ -111,6 +143,41 @@ func (c Constructor[T]) Build() (fn Builder[T]) {
 	return
 }
 
+// Validate - проверка конструктора ошибки.
+// Для сообщения по шаблону проверяется соответствие параметров и аргументов,
+// для схемы деталей - ее описание и типы значений деталей по умолчанию.
+func (c Constructor[T]) Validate() (err error) {
+	if m, ok := c.Message.(types.TemplateMessage); ok {
+		if err = m.Validate(); err != nil {
+			return fmt.Errorf("constructor '%s': %w", c.ID, err)
+		}
+	}
+
+	if c.Schema != nil {
+		if err = c.Schema.Validate(); err != nil {
+			return fmt.Errorf("constructor '%s': %w", c.ID, err)
+		}
+
+		if err = c.Schema.CheckValues(c.Details); err != nil {
+			return fmt.Errorf("constructor '%s': %w", c.ID, err)
+		}
+	}
+
+	return
+}
+
+// JSONSchema - получение схемы деталей ошибки в формате JSON Schema, см. details.Schema.JSONSchema.
+// Для конструктора без схемы описывается объект деталей с любыми ключами.
+func (c Constructor[T]) JSONSchema() (data []byte, err error) {
+	var schema = c.Schema
+
+	if schema == nil {
+		schema = new(details.Schema)
+	}
+
+	return schema.JSONSchema(string(c.ID))
+}
+
 // RestAPI - записать данные конструктора rest api ошибок.
 func (c Constructor[T]) RestAPI(cstr RestAPIConstructor) Constructor[T] {
 	if c.addons == nil {
@@ -131,6 +198,16 @@ func (c Constructor[T]) WebSocket(cstr WebSocketConstructor) Constructor[T] {
 	return c
 }
 
+// Grpc - записать данные конструктора grpc ошибок.
+func (c Constructor[T]) Grpc(cstr GrpcConstructor) Constructor[T] {
+	if c.addons == nil {
+		c.addons = new(constructorAddons)
+	}
+
+	c.addons.Grpc = &cstr
+	return c
+}
+
 // fillEmptyField - заполнение пустых полей структуры.
 func (c *Constructor[T]) fillEmptyField() *Constructor[T] {
 	if c.Message == nil {
@@ -1,10 +1,15 @@
 package errors
 
 import (
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"reflect"
 	"sm-errors/entities/details"
 	"sm-errors/entities/messages"
 	"sm-errors/types"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -1835,3 +1840,385 @@ func TestConstructor_fillEmptyField_WithGrpc(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructor_Build_Independent(t *testing.T) {
+	var (
+		builder = Constructor[RestAPI]{
+			ID:     "T-000001",
+			Type:   types.TypeSystem,
+			Status: types.StatusFatal,
+
+			Message: new(messages.TextMessage).
+				Text("Example error. "),
+			Details: new(details.Details).
+				Set("key", "value"),
+		}.RestAPI(
+			RestAPIConstructor{
+				StatusCode: 500,
+			}).Build()
+
+		wg sync.WaitGroup
+	)
+
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+
+		go func(n int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				var (
+					e   = builder()
+					msg = fmt.Sprintf("Goroutine %d, iteration %d. ", n, j)
+				)
+
+				e.SetError(fmt.Errorf("cause %d", n))
+				e.SetMessage(new(messages.TextMessage).Text(msg))
+				e.Details().Set("key", n)
+
+				if e.Message() != msg {
+					t.Errorf("Message() = %v, want %v", e.Message(), msg)
+					return
+				}
+
+				if v := e.Details().Peek("key"); v != n {
+					t.Errorf("Details().Peek() = %v, want %v", v, n)
+					return
+				}
+			}
+		}(n)
+	}
+
+	wg.Wait()
+
+	var e = builder()
+
+	if e.Message() != "Example error. " {
+		t.Errorf("Message() = %v, want %v", e.Message(), "Example error. ")
+	}
+
+	if e.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want nil", e.Unwrap())
+	}
+
+	if v := e.Details().Peek("key"); v != "value" {
+		t.Errorf("Details().Peek() = %v, want %v", v, "value")
+	}
+}
+
+func TestConstructor_Build_StackTrace(t *testing.T) {
+	tests := []struct {
+		name       string
+		global     types.StackMode
+		mode       types.StackMode
+		wantFrames bool
+	}{
+		{
+			name:       "Case 1",
+			global:     types.StackModeOff,
+			mode:       types.StackModeDefault,
+			wantFrames: false,
+		},
+		{
+			name:       "Case 2",
+			global:     types.StackModeCaller,
+			mode:       types.StackModeDefault,
+			wantFrames: true,
+		},
+		{
+			name:       "Case 3",
+			global:     types.StackModeFull,
+			mode:       types.StackModeOff,
+			wantFrames: false,
+		},
+		{
+			name:       "Case 4",
+			global:     types.StackModeOff,
+			mode:       types.StackModeFull,
+			wantFrames: true,
+		},
+	}
+
+	defer SetStackMode(types.StackModeOff)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStackMode(tt.global)
+
+			var builder = Constructor[WebSocket]{
+				ID:        "T-000001",
+				Type:      types.TypeSystem,
+				Status:    types.StatusFatal,
+				StackMode: tt.mode,
+
+				Message: new(messages.TextMessage).
+					Text("Example error. "),
+			}.WebSocket(
+				WebSocketConstructor{
+					StatusCode: 1011,
+				}).Build()
+
+			var frames = builder().StackTrace().Frames()
+
+			if (len(frames) > 0) != tt.wantFrames {
+				t.Errorf("StackTrace() frames = %d, want frames %v", len(frames), tt.wantFrames)
+				return
+			}
+
+			if tt.wantFrames && !strings.HasSuffix(frames[0].Function, "TestConstructor_Build_StackTrace.func1") {
+				t.Errorf("StackTrace() first frame = %v, want builder caller", frames[0].Function)
+			}
+		})
+	}
+}
+
+func TestConstructor_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Constructor[Error]
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			c: Constructor[Error]{
+				ID:      "T-000001",
+				Message: new(messages.TextMessage).Text("Test {value}"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			c: Constructor[Error]{
+				ID: "T-000001",
+				Message: new(messages.TemplateMessage).
+					Template("User {user_id} not found").
+					Params("user_id"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			c: Constructor[Error]{
+				ID: "T-000001",
+				Message: new(messages.TemplateMessage).
+					Template("User {user_id} not found").
+					Params("id"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 4",
+			c: Constructor[Error]{
+				ID: "T-000001",
+				Message: new(messages.TemplateMessage).
+					Template("User {user_id} not found").
+					Arg("name", "test"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 5",
+			c: Constructor[Error]{
+				ID:      "T-000001",
+				Details: new(details.Details).Set("reason", "blocked"),
+				Schema: &details.Schema{
+					Keys: []details.SchemaKey{
+						{Key: "user_id", Type: details.SchemaInt, Required: true},
+						{Key: "reason", Type: details.SchemaString},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 6",
+			c: Constructor[Error]{
+				ID:      "T-000001",
+				Details: new(details.Details).Set("user_id", "7"),
+				Schema: &details.Schema{
+					Keys: []details.SchemaKey{
+						{Key: "user_id", Type: details.SchemaInt, Required: true},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 7",
+			c: Constructor[Error]{
+				ID: "T-000001",
+				Schema: &details.Schema{
+					Keys: []details.SchemaKey{
+						{Key: "user_id", Type: "uuid"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var panicked = func() (ok bool) {
+				defer func() {
+					ok = recover() != nil
+				}()
+
+				tt.c.Build()
+
+				return
+			}()
+
+			if panicked != tt.wantErr {
+				t.Errorf("Build() panic = %v, want %v", panicked, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConstructor_Build_TemplateMessage(t *testing.T) {
+	var fn = Constructor[RestAPI]{
+		ID: "U-000001",
+		Message: new(messages.TemplateMessage).
+			Key("U-000001").
+			Template("User {user_id} not found"),
+	}.Build()
+
+	var e = fn()
+	e.SetMessageArgs(map[string]any{
+		"user_id": 42,
+	})
+
+	if got := e.Message(); got != "User 42 not found" {
+		t.Errorf("Message() = %v, want %v", got, "User 42 not found")
+	}
+
+	if got := fn().Message(); got != "User {user_id} not found" {
+		t.Errorf("Message() = %v, want %v", got, "User {user_id} not found")
+	}
+
+	var data, err = e.MarshalJSON()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"id":"U-000001","type":"unknown","status":"unknown","message":{"kind":"template","key":"U-000001","template":"User {user_id} not found","args":{"user_id":42},"text":"User 42 not found"},"details":{}}`
+
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+
+	var e_ = fn()
+
+	if err = e_.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := e_.Message(); got != "User 42 not found" {
+		t.Errorf("Message() = %v, want %v", got, "User 42 not found")
+	}
+}
+
+func TestConstructor_Build_Schema(t *testing.T) {
+	var reported []error
+
+	details.SetSchemaHandler(func(err error) {
+		reported = append(reported, err)
+	})
+
+	defer details.SetSchemaHandler(details.LogSchemaViolation)
+
+	var fn = Constructor[RestAPI]{
+		ID: "U-000001",
+		Schema: &details.Schema{
+			Keys: []details.SchemaKey{
+				{Key: "user_id", Type: details.SchemaInt, Required: true},
+			},
+		},
+	}.RestAPI(RestAPIConstructor{
+		StatusCode: 404,
+	}).Build()
+
+	tests := []struct {
+		name  string
+		value any
+		xml   bool
+		want  string
+	}{
+		{name: "Case 1", value: 42, want: ""},
+		{name: "Case 2", value: "42", want: "error 'U-000001': details: schema violation: 'user_id': expected int, got string"},
+		{name: "Case 3", value: nil, want: "error 'U-000001': details: schema violation: 'user_id': required key is missing"},
+		{name: "Case 4", value: "42", xml: true, want: "error 'U-000001': details: schema violation: 'user_id': expected int, got string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reported = nil
+
+			var e = fn()
+
+			if tt.value != nil {
+				e.Details().Set("user_id", tt.value)
+			}
+
+			var err error
+
+			if tt.xml {
+				_, err = xml.Marshal(e)
+			} else {
+				_, err = e.MarshalJSON()
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want == "" {
+				if len(reported) != 0 {
+					t.Errorf("MarshalJSON() reported = %v, want none", reported)
+				}
+
+				return
+			}
+
+			if len(reported) != 1 || reported[0].Error() != tt.want || !errors.Is(reported[0], details.ErrSchemaViolation) {
+				t.Errorf("MarshalJSON() reported = %v, want %v", reported, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstructor_JSONSchema(t *testing.T) {
+	var c = Constructor[Error]{
+		ID: "U-000001",
+		Schema: &details.Schema{
+			Keys: []details.SchemaKey{
+				{Key: "user_id", Type: details.SchemaInt, Required: true},
+			},
+		},
+	}
+
+	var data, err = c.JSONSchema()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"U-000001","type":"object",` +
+		`"properties":{"fields":{"type":"object"},"user_id":{"type":"integer"},"violations":{"type":"object"}},"required":["user_id"]}`
+
+	if string(data) != want {
+		t.Errorf("JSONSchema() = %s, want %s", data, want)
+	}
+
+	c.Schema = nil
+
+	if data, err = c.JSONSchema(); err != nil || !strings.Contains(string(data), `"title":"U-000001"`) {
+		t.Errorf("JSONSchema() = %s, %v", data, err)
+	}
+}
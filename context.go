@@ -0,0 +1,19 @@
+package errors
+
+import (
+	"sm-errors/internal"
+	"sm-errors/types"
+)
+
+// RegisterContextExtractors - зарегистрировать функции переноса данных из контекста в детали ошибок.
+// Функции применяются при установке контекста ошибки через WithContext и повторно при упаковке ошибки
+// в JSON и XML, поэтому значения вроде оставшегося до крайнего срока
+// времени соответствуют моменту упаковки.
+func RegisterContextExtractors(extractors ...types.ContextExtractor) {
+	internal.RegisterContextExtractors(extractors...)
+}
+
+// ResetContextExtractors - сбросить зарегистрированные функции переноса данных из контекста.
+func ResetContextExtractors() {
+	internal.ResetContextExtractors()
+}
@@ -0,0 +1,237 @@
+package details
+
+import (
+	"reflect"
+	"sm-errors/types"
+)
+
+// Cloner - описание значения деталей, которое создает свою копию само.
+// Значения с методом Clone без аргументов, возвращающим значение того же типа
+// (например, сообщения и детали ошибок), также копируются этим методом.
+type Cloner interface {
+	Clone() any
+}
+
+// cloneVisit - ключ уже скопированного значения для обработки циклических ссылок.
+type cloneVisit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// cloneValue - глубокое копирование значения с сохранением типов.
+// Каналы и функции не копируются и разделяются с исходным значением,
+// неэкспортируемые поля структур копируются поверхностно.
+func cloneValue(v any) any {
+	switch v.(type) {
+	case nil, string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return v
+	}
+
+	var c = cloneReflect(reflect.ValueOf(v), make(map[cloneVisit]reflect.Value))
+
+	if !c.IsValid() {
+		return nil
+	}
+
+	return c.Interface()
+}
+
+// cloneReflect - глубокое копирование значения через отражение.
+func cloneReflect(v reflect.Value, seen map[cloneVisit]reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	if c, ok := cloneByMethod(v); ok {
+		return c
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		{
+			if v.IsNil() {
+				return v
+			}
+
+			var visit = cloneVisit{ptr: v.Pointer(), typ: v.Type()}
+
+			if c, ok := seen[visit]; ok {
+				return c
+			}
+
+			var c = reflect.New(v.Type().Elem())
+			seen[visit] = c
+
+			c.Elem().Set(cloneReflect(v.Elem(), seen))
+
+			return c
+		}
+	case reflect.Interface:
+		{
+			if v.IsNil() {
+				return v
+			}
+
+			var c = reflect.New(v.Type()).Elem()
+			c.Set(cloneReflect(v.Elem(), seen))
+
+			return c
+		}
+	case reflect.Map:
+		{
+			if v.IsNil() {
+				return v
+			}
+
+			var visit = cloneVisit{ptr: v.Pointer(), typ: v.Type()}
+
+			if c, ok := seen[visit]; ok {
+				return c
+			}
+
+			var c = reflect.MakeMapWithSize(v.Type(), v.Len())
+			seen[visit] = c
+
+			for iter := v.MapRange(); iter.Next(); {
+				c.SetMapIndex(cloneReflect(iter.Key(), seen), cloneReflect(iter.Value(), seen))
+			}
+
+			return c
+		}
+	case reflect.Slice:
+		{
+			if v.IsNil() {
+				return v
+			}
+
+			var visit = cloneVisit{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+
+			if c, ok := seen[visit]; ok {
+				return c
+			}
+
+			var c = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			seen[visit] = c
+
+			if isPlainKind(v.Type().Elem().Kind()) {
+				reflect.Copy(c, v)
+				return c
+			}
+
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(cloneReflect(v.Index(i), seen))
+			}
+
+			return c
+		}
+	case reflect.Array:
+		{
+			var c = reflect.New(v.Type()).Elem()
+			c.Set(v)
+
+			if isPlainKind(v.Type().Elem().Kind()) {
+				return c
+			}
+
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(cloneReflect(v.Index(i), seen))
+			}
+
+			return c
+		}
+	case reflect.Struct:
+		{
+			var c = reflect.New(v.Type()).Elem()
+			c.Set(v)
+
+			for i := 0; i < v.NumField(); i++ {
+				if field := c.Field(i); field.CanSet() {
+					field.Set(cloneReflect(v.Field(i), seen))
+				}
+			}
+
+			return c
+		}
+	}
+
+	return v
+}
+
+// cloneByMethod - копирование значения его собственным методом Clone.
+func cloneByMethod(v reflect.Value) (c reflect.Value, ok bool) {
+	if !v.CanInterface() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return
+	}
+
+	if cloner, isCloner := v.Interface().(Cloner); isCloner {
+		c = reflect.ValueOf(cloner.Clone())
+	} else {
+		var method = v.MethodByName("Clone")
+
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			return
+		}
+
+		c = method.Call(nil)[0]
+	}
+
+	if c.Kind() == reflect.Interface {
+		c = c.Elem()
+	}
+
+	if !c.IsValid() || !c.Type().AssignableTo(v.Type()) {
+		return reflect.Value{}, false
+	}
+
+	if v.Kind() == reflect.Interface {
+		var i = reflect.New(v.Type()).Elem()
+		i.Set(c)
+		c = i
+	}
+
+	return c, true
+}
+
+// isPlainKind - проверка, копируется ли значение вида простым присваиванием.
+func isPlainKind(k reflect.Kind) (ok bool) {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+
+	return
+}
+
+// cloneViolations - копирование списка нарушений правил поля.
+// При глубоком копировании копируются также параметры и сообщения нарушений.
+func cloneViolations(violations []types.DetailsFieldViolation, deep bool) (list []types.DetailsFieldViolation) {
+	if violations == nil {
+		return
+	}
+
+	list = make([]types.DetailsFieldViolation, len(violations))
+	copy(list, violations)
+
+	if !deep {
+		return
+	}
+
+	for i, v := range list {
+		if v.Params != nil {
+			list[i].Params = cloneValue(v.Params).(map[string]any)
+		}
+
+		if v.Message != nil {
+			list[i].Message = v.Message.Clone()
+		}
+	}
+
+	return
+}
@@ -0,0 +1,233 @@
+package details
+
+import (
+	"encoding/json"
+	"reflect"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+	"time"
+)
+
+// testCloneValue - значение деталей со своим копированием для тестов.
+type testCloneValue struct {
+	ID     int
+	cloned bool
+}
+
+// Clone - копирование значения.
+func (v *testCloneValue) Clone() any {
+	return &testCloneValue{ID: v.ID, cloned: true}
+}
+
+// testCloneStruct - структура для тестов копирования.
+type testCloneStruct struct {
+	Name   string
+	Tags   []string
+	Meta   map[string]any
+	Next   *testCloneStruct
+	hidden int
+}
+
+func Test_cloneValue(t *testing.T) {
+	var date = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		v    any
+		want any
+	}{
+		{
+			name: "Case 1",
+			v:    nil,
+			want: nil,
+		},
+		{
+			name: "Case 2",
+			v:    42,
+			want: 42,
+		},
+		{
+			name: "Case 3",
+			v:    int64(1) << 60,
+			want: int64(1) << 60,
+		},
+		{
+			name: "Case 4",
+			v:    date,
+			want: date,
+		},
+		{
+			name: "Case 5",
+			v:    []int{1, 2, 3},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "Case 6",
+			v:    map[string]any{"count": 3, "tags": []string{"a"}},
+			want: map[string]any{"count": 3, "tags": []string{"a"}},
+		},
+		{
+			name: "Case 7",
+			v:    &testCloneStruct{Name: "test", Tags: []string{"a"}, hidden: 7},
+			want: &testCloneStruct{Name: "test", Tags: []string{"a"}, hidden: 7},
+		},
+		{
+			name: "Case 8",
+			v:    [2]*int{},
+			want: [2]*int{},
+		},
+		{
+			name: "Case 9",
+			v:    &testCloneValue{ID: 1},
+			want: &testCloneValue{ID: 1, cloned: true},
+		},
+		{
+			name: "Case 10",
+			v:    new(messages.TextMessage).Text("Message. "),
+			want: new(messages.TextMessage).Text("Message. "),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cloneValue(tt.v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cloneValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cloneValue_Independent(t *testing.T) {
+	var v = &testCloneStruct{
+		Name: "test",
+		Tags: []string{"a", "b"},
+		Meta: map[string]any{"list": []any{1, "x"}},
+	}
+
+	var c = cloneValue(v).(*testCloneStruct)
+
+	c.Tags[0] = "changed"
+	c.Meta["list"].([]any)[0] = 2
+	c.Meta["new"] = true
+
+	if v.Tags[0] != "a" || v.Meta["list"].([]any)[0] != 1 || len(v.Meta) != 1 {
+		t.Errorf("cloneValue() shares data with source: %#v", v)
+	}
+
+	var m = new(messages.TextMessage).Text("Message. ")
+
+	if c := cloneValue(m); c == types.Message(m) {
+		t.Errorf("cloneValue() returned the same message")
+	}
+}
+
+func Test_cloneValue_Cycle(t *testing.T) {
+	var v = &testCloneStruct{Name: "first"}
+	v.Next = &testCloneStruct{Name: "second", Next: v}
+
+	var c = cloneValue(v).(*testCloneStruct)
+
+	if c == v || c.Next == v.Next {
+		t.Fatalf("cloneValue() returned shared pointers")
+	}
+
+	if c.Next.Next != c {
+		t.Errorf("cloneValue() cycle is not preserved")
+	}
+
+	var m = map[string]any{}
+	m["self"] = m
+
+	var cm = cloneValue(m).(map[string]any)
+
+	if reflect.ValueOf(cm["self"]).Pointer() != reflect.ValueOf(cm).Pointer() {
+		t.Errorf("cloneValue() map cycle is not preserved")
+	}
+}
+
+func Test_cloneValue_Shared(t *testing.T) {
+	var (
+		ch = make(chan int)
+		fn = func() {}
+	)
+
+	if c := cloneValue(ch); c != any(ch) {
+		t.Errorf("cloneValue() channel = %v, want %v", c, ch)
+	}
+
+	if c := cloneValue(fn); reflect.ValueOf(c).Pointer() != reflect.ValueOf(fn).Pointer() {
+		t.Errorf("cloneValue() func is not shared")
+	}
+}
+
+func TestDetails_Clone_Types(t *testing.T) {
+	var (
+		date = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		ds   = new(Details).
+			Set("attempt", 3).
+			Set("date", date).
+			Set("user", &testCloneStruct{Name: "test"}).
+			Set("value", &testCloneValue{ID: 1})
+	)
+
+	var c = ds.Clone()
+
+	if got := c.Peek("attempt"); got != any(3) {
+		t.Errorf("Clone() attempt = %#v, want %#v", got, 3)
+	}
+
+	if got := c.Peek("date"); got != any(date) {
+		t.Errorf("Clone() date = %#v, want %#v", got, date)
+	}
+
+	if got, ok := c.Peek("user").(*testCloneStruct); !ok || got == ds.Peek("user") || got.Name != "test" {
+		t.Errorf("Clone() user = %#v", c.Peek("user"))
+	}
+
+	if got, ok := c.Peek("value").(*testCloneValue); !ok || !got.cloned {
+		t.Errorf("Clone() value = %#v", c.Peek("value"))
+	}
+}
+
+// newBenchmarkDetails - детали ошибки для измерения копирования.
+func newBenchmarkDetails() *Details {
+	var ds = new(Details)
+
+	ds.Set("user_id", "u-000001").
+		Set("attempt", 3).
+		Set("ratio", 0.75).
+		Set("tags", []string{"auth", "login", "retry"}).
+		Set("meta", map[string]any{
+			"ip":      "127.0.0.1",
+			"headers": map[string]any{"accept": "application/json", "x-request-id": "r-1"},
+			"ids":     []any{1, 2, 3, 4, 5},
+		}).
+		Set("user", &testCloneStruct{Name: "test", Tags: []string{"a", "b"}})
+
+	return ds
+}
+
+func BenchmarkDetails_Clone(b *testing.B) {
+	var ds = newBenchmarkDetails()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ds.Clone()
+	}
+}
+
+func BenchmarkDetails_Clone_JSON(b *testing.B) {
+	var ds = newBenchmarkDetails()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var storage = make(map[string]any)
+
+		if data, err := json.Marshal(ds.storage); err == nil {
+			_ = json.Unmarshal(data, &storage)
+		}
+	}
+}
@@ -1,33 +1,33 @@
 package details
 
 import (
-	"encoding/json"
 	"sm-errors/types"
 	"sync"
 )
 
 type (
 	// Details - детали для ошибок.
+	// Нулевое значение готово к использованию, все методы безопасны для одновременного вызова.
 	Details struct {
-		fields Fields
+		fields   Fields
+		notation FieldKeyNotation
+		order    KeyOrder
 
 		storage map[string]any
-		rwMux   *sync.RWMutex
+		keys    []string
+		rwMux   sync.RWMutex
 	}
 
 	Fields []*types.DetailsField
 )
 
 // init - инициализация хранилища.
+// Вызывается только под блокировкой на запись, мьютекс деталей готов к работе без инициализации.
 func (ds *Details) init() {
 	if ds.storage == nil {
 		ds.storage = make(map[string]any)
 	}
 
-	if ds.rwMux == nil {
-		ds.rwMux = new(sync.RWMutex)
-	}
-
 	if ds.fields == nil {
 		ds.fields = make([]*types.DetailsField, 0)
 	}
@@ -37,8 +37,6 @@ func (ds *Details) init() {
 
 // Peek - получение данных из хранилища.
 func (ds *Details) Peek(k string) (v any) {
-	ds.init()
-
 	ds.rwMux.RLock()
 	defer ds.rwMux.RUnlock()
 
@@ -46,12 +44,17 @@ func (ds *Details) Peek(k string) (v any) {
 }
 
 // Set - установить значение в хранилище по ключу.
+// Ключи "fields" и "violations" зарезервированы для полей и при упаковке экранируются, см. EscapeKey.
 func (ds *Details) Set(k string, v any) types.Details {
-	ds.init()
-
 	ds.rwMux.Lock()
 	defer ds.rwMux.Unlock()
 
+	ds.init()
+
+	if _, ok := ds.storage[k]; !ok {
+		ds.keys = append(ds.keys, k)
+	}
+
 	ds.storage[k] = v
 
 	return ds
@@ -59,16 +62,21 @@ func (ds *Details) Set(k string, v any) types.Details {
 
 // Reset - сбросить детали.
 func (ds *Details) Reset() types.Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
 	ds.init()
 
 	ds.storage = make(map[string]any)
+	ds.keys = nil
 
 	return ds
 }
 
 // PeekFieldMessage - получить сообщение поля.
 func (ds *Details) PeekFieldMessage(k string) (m types.DetailsFieldMessage) {
-	ds.init()
+	ds.rwMux.RLock()
+	defer ds.rwMux.RUnlock()
 
 	for _, f := range ds.fields {
 		if k == f.Key.String() {
@@ -80,9 +88,45 @@ func (ds *Details) PeekFieldMessage(k string) (m types.DetailsFieldMessage) {
 	return
 }
 
+// PeekFieldViolations - получить копию списка нарушений правил поля.
+func (ds *Details) PeekFieldViolations(k string) (violations []types.DetailsFieldViolation) {
+	ds.rwMux.RLock()
+	defer ds.rwMux.RUnlock()
+
+	for _, f := range ds.fields {
+		if k == f.Key.String() {
+			return cloneViolations(f.Violations, false)
+		}
+	}
+
+	return
+}
+
+// Fields - получение копии списка полей ошибки.
+// Ключи и сообщения полей не копируются.
+func (ds *Details) Fields() (fields []types.DetailsField) {
+	ds.rwMux.RLock()
+	defer ds.rwMux.RUnlock()
+
+	fields = make([]types.DetailsField, 0, len(ds.fields))
+
+	for _, f := range ds.fields {
+		var f_ = *f
+		f_.Violations = cloneViolations(f.Violations, false)
+
+		fields = append(fields, f_)
+	}
+
+	return
+}
+
 // SetField - установить значение поля ошибки.
-// В случае пересечения ключей поля будет вставлено новое значение.
+// Ключи полей сравниваются по значению.
+// В случае пересечения ключей поля будет вставлено новое значение, нарушения правил поля сбрасываются.
 func (ds *Details) SetField(k types.DetailsFieldKey, m types.DetailsFieldMessage) types.Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
 	ds.init()
 
 	var f = &types.DetailsField{
@@ -93,8 +137,9 @@ func (ds *Details) SetField(k types.DetailsFieldKey, m types.DetailsFieldMessage
 	var found bool
 
 	for _, field := range ds.fields {
-		if f.Key == field.Key {
+		if equalFieldKeys(f.Key, field.Key) {
 			field.Message = f.Message
+			field.Violations = nil
 			found = true
 			break
 		}
@@ -108,15 +153,22 @@ func (ds *Details) SetField(k types.DetailsFieldKey, m types.DetailsFieldMessage
 }
 
 // SetFields - установить значение полей ошибки.
-// В случае пересечения ключей поля будет вставлено новое значение.
+// Ключи полей сравниваются по значению.
+// В случае пересечения ключей поля будет вставлено новое значение вместе с нарушениями правил.
 func (ds *Details) SetFields(fields ...types.DetailsField) types.Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
 	ds.init()
 
 For:
 	for _, newField := range fields {
+		newField.Violations = cloneViolations(newField.Violations, false)
+
 		for _, field := range ds.fields {
-			if newField.Key == field.Key {
+			if equalFieldKeys(newField.Key, field.Key) {
 				field.Message = newField.Message
+				field.Violations = newField.Violations
 				continue For
 			}
 		}
@@ -127,8 +179,41 @@ For:
 	return ds
 }
 
+// AddFieldViolation - добавить нарушение правила поля.
+// Если поля нет, оно создается с сообщением нарушения в качестве основного сообщения.
+// Если у поля нет основного сообщения, им становится сообщение нарушения.
+func (ds *Details) AddFieldViolation(k types.DetailsFieldKey, v types.DetailsFieldViolation) types.Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.init()
+
+	for _, field := range ds.fields {
+		if equalFieldKeys(k, field.Key) {
+			if field.Message == nil {
+				field.Message = v.Message
+			}
+
+			field.Violations = append(field.Violations, v)
+
+			return ds
+		}
+	}
+
+	ds.fields = append(ds.fields, &types.DetailsField{
+		Key:        k,
+		Message:    v.Message,
+		Violations: []types.DetailsFieldViolation{v},
+	})
+
+	return ds
+}
+
 // ResetFields - сбросить поля.
 func (ds *Details) ResetFields() types.Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
 	ds.init()
 
 	ds.fields = make([]*types.DetailsField, 0)
@@ -136,35 +221,91 @@ func (ds *Details) ResetFields() types.Details {
 	return ds
 }
 
+// SetLocales - установить предпочтительные языки для сообщений полей и нарушений правил.
+// Локализуемые сообщения копируются перед изменением, сообщения, общие с другими деталями, не изменяются.
+func (ds *Details) SetLocales(locales ...string) *Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.init()
+
+	for _, f := range ds.fields {
+		f.Message = LocalizeMessage(f.Message, locales...)
+
+		for i := range f.Violations {
+			f.Violations[i].Message = LocalizeMessage(f.Violations[i].Message, locales...)
+		}
+	}
+
+	return ds
+}
+
+// LocalizeMessage - получение копии локализуемого сообщения с предпочтительными языками.
+// Остальные сообщения возвращаются без изменений.
+func LocalizeMessage(m types.Message, locales ...string) (m_ types.Message) {
+	if _, ok := m.(types.LocalizedMessage); !ok {
+		return m
+	}
+
+	m_ = m.Clone()
+
+	if lm, ok := m_.(types.LocalizedMessage); ok {
+		lm.SetLocales(locales...)
+	}
+
+	return
+}
+
+// Notation - установить запись ключей полей при упаковке деталей ошибки.
+// По умолчанию используется запись, установленная SetDefaultFieldKeyNotation.
+func (ds *Details) Notation(n FieldKeyNotation) *Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.init()
+
+	ds.notation = n
+
+	return ds
+}
+
 // Clone - копирование деталей ошибки.
+// Значения копируются глубоко с сохранением типов, см. Cloner.
 func (ds *Details) Clone() types.Details {
-	ds.init()
+	ds.rwMux.RLock()
+	defer ds.rwMux.RUnlock()
 
 	var ds_ = &Details{
-		fields:  make([]*types.DetailsField, 0),
-		storage: make(map[string]any),
-		rwMux:   new(sync.RWMutex),
+		fields:   make([]*types.DetailsField, 0),
+		notation: ds.notation,
+		order:    ds.order,
+		storage:  make(map[string]any),
+		keys:     append([]string(nil), ds.keys...),
 	}
 
 	// storage
 	{
-		if len(ds.storage) > 0 {
-			if data, err := json.Marshal(ds.storage); err == nil {
-				if err = json.Unmarshal(data, &ds_.storage); err != nil {
-					ds_.storage = make(map[string]any)
-				}
-			}
+		for k, v := range ds.storage {
+			ds_.storage[k] = cloneValue(v)
 		}
 	}
 
 	// fields
 	{
 		for _, f := range ds.fields {
-			ds_.SetField(f.Key.Clone(), f.Message.Clone())
+			var m types.DetailsFieldMessage
+
+			if f.Message != nil {
+				m = f.Message.Clone()
+			}
+
+			ds_.fields = append(ds_.fields, &types.DetailsField{
+				Key:        f.Key.Clone(),
+				Message:    m,
+				Violations: cloneViolations(f.Violations, true),
+			})
 		}
 	}
 
-	ds_.init()
-
 	return ds_
 }
@@ -0,0 +1,118 @@
+package details
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"sync"
+	"testing"
+)
+
+// runConcurrently - запуск функций в нескольких горутинах одновременно.
+func runConcurrently(workers, iterations int, fns ...func(worker, i int)) {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+	)
+
+	for w := 0; w < workers; w++ {
+		for _, fn := range fns {
+			wg.Add(1)
+
+			go func(worker int, fn func(worker, i int)) {
+				defer wg.Done()
+
+				<-start
+
+				for i := 0; i < iterations; i++ {
+					fn(worker, i)
+				}
+			}(w, fn)
+		}
+	}
+
+	close(start)
+	wg.Wait()
+}
+
+func TestDetails_Concurrent(t *testing.T) {
+	// Детали не инициализированы: первая операция выполняется одновременно из нескольких горутин.
+	var ds = new(Details)
+
+	runConcurrently(4, 100,
+		func(worker, i int) {
+			ds.Set(fmt.Sprintf("key-%d", worker), i)
+			ds.Peek(fmt.Sprintf("key-%d", worker))
+		},
+		func(worker, i int) {
+			var key = new(FieldKey).Add(fmt.Sprintf("field-%d", worker)).AddArray("items", i%4)
+
+			ds.SetField(key, new(messages.TextMessage).Text("Message. "))
+			ds.PeekFieldMessage(key.String())
+		},
+		func(worker, i int) {
+			ds.SetFields(types.DetailsField{
+				Key:     new(FieldKey).Add("shared"),
+				Message: new(messages.TextMessage).Text(fmt.Sprint(i)),
+			})
+		},
+		func(worker, i int) {
+			ds.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "min_length",
+				Params:  map[string]any{"min": 8},
+				Message: new(messages.TextMessage).Text("Too short"),
+			})
+			ds.PeekFieldViolations("password")
+		},
+		func(worker, i int) {
+			for _, f := range ds.Fields() {
+				_ = f.Key.String()
+			}
+
+			ds.Clone()
+		},
+		func(worker, i int) {
+			if _, err := json.Marshal(ds); err != nil {
+				t.Error(err)
+			}
+
+			if _, err := xml.Marshal(ds); err != nil {
+				t.Error(err)
+			}
+		},
+		func(worker, i int) {
+			if i%50 == 0 {
+				ds.Reset()
+				ds.ResetFields()
+			}
+
+			if i%100 == 0 {
+				ds.Notation(FieldKeyNotationJSONPointer)
+			}
+		},
+	)
+}
+
+func TestDetails_Concurrent_Init(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		var ds = new(Details)
+
+		runConcurrently(4, 1,
+			func(worker, i int) { ds.Set("key", worker) },
+			func(worker, i int) { ds.SetField(new(FieldKey).Add("field"), new(messages.TextMessage).Text("1")) },
+			func(worker, i int) { ds.Peek("key") },
+			func(worker, i int) { ds.Fields() },
+			func(worker, i int) { ds.Clone() },
+		)
+
+		if got := len(ds.Fields()); got != 1 {
+			t.Fatalf("Fields() = %d fields, want 1", got)
+		}
+
+		if ds.Peek("key") == nil {
+			t.Fatalf("Peek() = nil, want value")
+		}
+	}
+}
@@ -1,34 +1,85 @@
 package details
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"io"
+	"sm-errors/types"
+	"sort"
 )
 
+// jsonMember - член объекта JSON.
+type jsonMember struct {
+	key   string
+	value any
+}
+
+// marshalJSONObject - упаковать объект JSON с сохранением порядка членов.
+func marshalJSONObject(members []jsonMember) (data []byte, err error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, m := range members {
+		var key, value []byte
+
+		if key, err = json.Marshal(m.key); err != nil {
+			return
+		}
+
+		if value, err = json.Marshal(m.value); err != nil {
+			return
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
 // MarshalJSON - упаковать в формат JSON.
+// Ключи хранилища упаковываются в порядке деталей, см. Order, за ними следуют "fields" и "violations".
+// При сортировке ключей "fields" и "violations" сортируются вместе с ключами хранилища.
+// Зарезервированные ключи хранилища экранируются, см. EscapeKey.
 func (ds *Details) MarshalJSON() ([]byte, error) {
-	ds.init()
-
 	ds.rwMux.RLock()
 	defer ds.rwMux.RUnlock()
 
-	var w = make(map[string]any)
+	var members = make([]jsonMember, 0, len(ds.storage)+2)
 
-	for k, v := range ds.storage {
-		w[k] = v
+	for _, k := range ds.storageKeys() {
+		members = append(members, jsonMember{key: EscapeKey(k), value: ds.storage[k]})
 	}
 
 	if len(ds.fields) > 0 {
-		w["fields"] = ds.fields
+		members = append(members, jsonMember{key: KeyFields, value: notatedFields{list: ds.fields, notation: ds.notation}})
+	}
+
+	// Нарушения правил упаковываются отдельно, поле "fields" сохраняет основные сообщения для старых клиентов.
+	if ds.fields.hasViolations() {
+		members = append(members, jsonMember{key: KeyViolations, value: notatedViolations{list: ds.fields, notation: ds.notation}})
 	}
 
-	return json.Marshal(w)
+	if ds.resolveOrder() == KeyOrderSorted {
+		sort.SliceStable(members, func(i, j int) bool { return members[i].key < members[j].key })
+	}
+
+	return marshalJSONObject(members)
 }
 
 // MarshalXML - упаковать в формат XML.
+// Ключи хранилища упаковываются в порядке деталей, см. Order, за ними следуют поля и нарушения.
+// Ключи вложенных карт сортируются, зарезервированные ключи хранилища экранируются, см. EscapeKey.
 func (ds *Details) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
-	ds.init()
-
 	ds.rwMux.RLock()
 	defer ds.rwMux.RUnlock()
 
@@ -39,21 +90,31 @@ func (ds *Details) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 		Attr: nil,
 	}
 
-	var w = make(map[string]any)
+	var (
+		w    = make(map[string]any)
+		keys = make([]string, 0, len(ds.storage)+2)
+	)
 
-	for k, v := range ds.storage {
-		w[k] = v
+	for _, k := range ds.storageKeys() {
+		w[EscapeKey(k)] = ds.storage[k]
+		keys = append(keys, EscapeKey(k))
 	}
 
 	if len(ds.fields) > 0 {
-		w["Fields"] = ds.fields
+		w[KeyFields] = notatedFields{list: ds.fields, notation: ds.notation}
+		keys = append(keys, KeyFields)
+	}
+
+	if ds.fields.hasViolations() {
+		w[KeyViolations] = notatedViolations{list: ds.fields, notation: ds.notation}
+		keys = append(keys, KeyViolations)
 	}
 
 	if err = e.EncodeToken(start); err != nil {
 		return
 	}
 
-	if err = ds.marshalXML(w, e, start); err != nil {
+	if err = ds.marshalXML(keys, w, e, start); err != nil {
 		return
 	}
 
@@ -64,9 +125,11 @@ func (ds *Details) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 	return nil
 }
 
-// marshalXML - упаковать в формат XML.
-func (ds *Details) marshalXML(w map[string]any, e *xml.Encoder, start xml.StartElement) (err error) {
-	for k, v := range w {
+// marshalXML - упаковать значения карты в формат XML в порядке ключей keys.
+func (ds *Details) marshalXML(keys []string, w map[string]any, e *xml.Encoder, start xml.StartElement) (err error) {
+	for _, k := range keys {
+		var v = w[k]
+
 		start = xml.StartElement{
 			Name: xml.Name{
 				Local: k,
@@ -79,7 +142,7 @@ func (ds *Details) marshalXML(w map[string]any, e *xml.Encoder, start xml.StartE
 				return
 			}
 
-			if err = ds.marshalXML(c, e, start); err != nil {
+			if err = ds.marshalXML(sortedKeys(c), c, e, start); err != nil {
 				return
 			}
 
@@ -111,3 +174,281 @@ func (ds *Details) marshalXML(w map[string]any, e *xml.Encoder, start xml.StartE
 
 	return
 }
+
+// UnmarshalXML - распаковать из формата XML.
+// Текущие значения и поля деталей заменяются распакованными, запись ключей и порядок сохраняются.
+// XML не передает типы значений: значения хранилища распаковываются строками, элементы со вложенными
+// элементами - картами, повторяющиеся ключи - списками []any. Элементы "fields" и "violations"
+// без вложенных элементов распаковываются как значения хранилища в формате до экранирования ключей.
+func (ds *Details) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	var ds_ = new(Details)
+
+	err = decodeXMLChildren(d, func(el xml.StartElement) (err error) {
+		if el.Name.Local != "Item" {
+			var v any
+
+			if v, err = decodeXMLValue(d, true); err != nil {
+				return
+			}
+
+			ds_.setXMLValue(UnescapeKey(el.Name.Local), v)
+
+			return
+		}
+
+		switch key := xmlAttr(el, "key"); key {
+		case KeyFields, KeyViolations:
+			{
+				var (
+					tokens []xml.Token
+					nested bool
+				)
+
+				if tokens, nested, err = readXMLElement(d); err != nil {
+					return
+				}
+
+				// До экранирования зарезервированных ключей значения хранилища с такими ключами
+				// передавались текстом, поля и нарушения всегда содержат вложенные элементы.
+				if !nested {
+					ds_.setXMLValue(key, xmlText(tokens))
+					return
+				}
+
+				var replay = xml.NewTokenDecoder(&xmlTokenReader{tokens: append([]xml.Token{el}, tokens...)})
+
+				if _, err = replay.Token(); err != nil {
+					return
+				}
+
+				if key == KeyViolations {
+					return decodeViolationsXML(replay, func(k types.DetailsFieldKey, v types.DetailsFieldViolation) {
+						ds_.AddFieldViolation(k, v)
+					})
+				}
+
+				var fields Fields
+
+				if err = fields.UnmarshalXML(replay, el); err != nil {
+					return
+				}
+
+				for _, f := range fields {
+					ds_.SetField(f.Key, f.Message)
+				}
+			}
+		default:
+			{
+				var v any
+
+				if v, err = decodeXMLValue(d, false); err != nil {
+					return
+				}
+
+				ds_.setXMLValue(UnescapeKey(key), v)
+			}
+		}
+
+		return
+	})
+
+	if err != nil {
+		return
+	}
+
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.storage = ds_.storage
+	ds.keys = ds_.keys
+	ds.fields = ds_.fields
+
+	// Пустой документ не создает хранилище у временных деталей.
+	ds.init()
+
+	return
+}
+
+// setXMLValue - установить распакованное значение, повторяющиеся ключи собираются в список.
+func (ds *Details) setXMLValue(k string, v any) {
+	var current = ds.Peek(k)
+
+	switch c := current.(type) {
+	case nil:
+		ds.Set(k, v)
+	case []any:
+		ds.Set(k, append(c, v))
+	default:
+		ds.Set(k, []any{c, v})
+	}
+
+	return
+}
+
+// decodeXMLValue - распаковать значение элемента XML.
+// Элемент со вложенными элементами распаковывается в карту по именам элементов или атрибутам key
+// элементов Item, элемент без вложенных элементов - в строку, если asMap не требует карту.
+func decodeXMLValue(d *xml.Decoder, asMap bool) (v any, err error) {
+	var (
+		text []byte
+		m    map[string]any
+	)
+
+	for {
+		var token xml.Token
+
+		if token, err = d.Token(); err != nil {
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			{
+				var (
+					key = t.Name.Local
+					c   any
+				)
+
+				if key == "Item" {
+					key = xmlAttr(t, "key")
+				}
+
+				if c, err = decodeXMLValue(d, key == t.Name.Local); err != nil {
+					return
+				}
+
+				if m == nil {
+					m = make(map[string]any)
+				}
+
+				switch current := m[key].(type) {
+				case nil:
+					m[key] = c
+				case []any:
+					m[key] = append(current, c)
+				default:
+					m[key] = []any{current, c}
+				}
+			}
+		case xml.CharData:
+			text = append(text, t...)
+		case xml.EndElement:
+			{
+				if m == nil && asMap {
+					m = make(map[string]any)
+				}
+
+				if m != nil {
+					return m, nil
+				}
+
+				return string(text), nil
+			}
+		}
+	}
+}
+
+// decodeXMLChildren - обход вложенных элементов XML до конца текущего элемента.
+// Функция fn должна прочитать элемент полностью, например через DecodeElement или Skip.
+func decodeXMLChildren(d *xml.Decoder, fn func(el xml.StartElement) (err error)) (err error) {
+	for {
+		var token xml.Token
+
+		if token, err = d.Token(); err != nil {
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			{
+				if err = fn(t); err != nil {
+					return
+				}
+			}
+		case xml.EndElement:
+			return
+		}
+	}
+}
+
+// readXMLElement - чтение токенов до конца текущего элемента, включая закрывающий токен.
+// Признак nested показывает, есть ли у элемента вложенные элементы.
+func readXMLElement(d *xml.Decoder) (tokens []xml.Token, nested bool, err error) {
+	for depth := 0; ; {
+		var token xml.Token
+
+		if token, err = d.Token(); err != nil {
+			return
+		}
+
+		tokens = append(tokens, xml.CopyToken(token))
+
+		switch token.(type) {
+		case xml.StartElement:
+			{
+				nested = true
+				depth++
+			}
+		case xml.EndElement:
+			{
+				if depth == 0 {
+					return
+				}
+
+				depth--
+			}
+		}
+	}
+}
+
+// xmlText - получение текста из токенов элемента.
+func xmlText(tokens []xml.Token) (text string) {
+	var b []byte
+
+	for _, token := range tokens {
+		if data, ok := token.(xml.CharData); ok {
+			b = append(b, data...)
+		}
+	}
+
+	return string(b)
+}
+
+// xmlTokenReader - повторное чтение сохраненных токенов XML.
+type xmlTokenReader struct {
+	tokens []xml.Token
+}
+
+// Token - получение следующего токена.
+func (r *xmlTokenReader) Token() (token xml.Token, err error) {
+	if len(r.tokens) == 0 {
+		return nil, io.EOF
+	}
+
+	token, r.tokens = r.tokens[0], r.tokens[1:]
+
+	return
+}
+
+// xmlAttr - получение значения атрибута элемента XML.
+func xmlAttr(el xml.StartElement, name string) (value string) {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return
+}
+
+// decodeFieldKey - разбор ключа поля при распаковке.
+// Запись ключа определяется автоматически, ключ, который не удалось разобрать, сохраняется как имя поля.
+func decodeFieldKey(k string) (key types.DetailsFieldKey) {
+	var err error
+
+	if key, err = ParseFieldKeyNotation(k, FieldKeyNotationDefault); err != nil {
+		key = new(FieldKey).Add(k)
+	}
+
+	return
+}
@@ -5,7 +5,7 @@ import (
 	"encoding/xml"
 	"reflect"
 	"sm-errors/entities/messages"
-	"sync"
+	"sm-errors/types"
 	"testing"
 )
 
@@ -13,7 +13,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -34,7 +33,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want:    []byte{123, 34, 102, 105, 101, 108, 100, 115, 34, 58, 123, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125, 44, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125},
 			wantErr: false,
@@ -46,7 +44,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want:    []byte{123, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125},
 			wantErr: false,
@@ -61,7 +58,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want:    []byte{123, 34, 102, 105, 101, 108, 100, 115, 34, 58, 123, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125, 125},
 			wantErr: false,
@@ -78,7 +74,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: nil,
 			},
 			want:    []byte{123, 34, 102, 105, 101, 108, 100, 115, 34, 58, 123, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125, 44, 34, 116, 101, 115, 116, 34, 58, 34, 49, 50, 51, 34, 125},
 			wantErr: false,
@@ -88,7 +83,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want:    []byte{123, 125},
 			wantErr: false,
@@ -100,7 +94,6 @@ func TestDetails_MarshalJSON(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 			got, err := json.Marshal(ds)
 
@@ -120,7 +113,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -141,7 +133,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want:    []byte{60, 68, 101, 116, 97, 105, 108, 115, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 73, 116, 101, 109, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 102, 105, 101, 108, 100, 115, 34, 62, 60, 70, 105, 101, 108, 100, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 70, 105, 101, 108, 100, 62, 60, 47, 73, 116, 101, 109, 62, 60, 47, 68, 101, 116, 97, 105, 108, 115, 62},
 			wantErr: false,
@@ -153,7 +144,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want:    []byte{60, 68, 101, 116, 97, 105, 108, 115, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 73, 116, 101, 109, 62, 60, 47, 68, 101, 116, 97, 105, 108, 115, 62},
 			wantErr: false,
@@ -168,7 +158,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want:    []byte{60, 68, 101, 116, 97, 105, 108, 115, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 102, 105, 101, 108, 100, 115, 34, 62, 60, 70, 105, 101, 108, 100, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 70, 105, 101, 108, 100, 62, 60, 47, 73, 116, 101, 109, 62, 60, 47, 68, 101, 116, 97, 105, 108, 115, 62},
 			wantErr: false,
@@ -185,7 +174,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: nil,
 			},
 			want:    []byte{60, 68, 101, 116, 97, 105, 108, 115, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 73, 116, 101, 109, 62, 60, 73, 116, 101, 109, 32, 107, 101, 121, 61, 34, 102, 105, 101, 108, 100, 115, 34, 62, 60, 70, 105, 101, 108, 100, 32, 107, 101, 121, 61, 34, 116, 101, 115, 116, 34, 62, 49, 50, 51, 60, 47, 70, 105, 101, 108, 100, 62, 60, 47, 73, 116, 101, 109, 62, 60, 47, 68, 101, 116, 97, 105, 108, 115, 62},
 			wantErr: false,
@@ -195,7 +183,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want:    []byte{60, 68, 101, 116, 97, 105, 108, 115, 62, 60, 47, 68, 101, 116, 97, 105, 108, 115, 62},
 			wantErr: false,
@@ -207,7 +194,6 @@ func TestDetails_MarshalXML(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 			got, err := xml.Marshal(ds)
 
@@ -222,3 +208,185 @@ func TestDetails_MarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestDetails_UnmarshalXML(t *testing.T) {
+	var ds = new(Details).Order(KeyOrderInsertion)
+
+	ds.Set("trace", "abc").
+		Set("attempts", []any{"1", "2"}).
+		Set("db", map[string]any{"host": "localhost", "port": "5432"})
+
+	ds.SetField(new(FieldKey).Add("user").AddArray("items", 3), new(messages.TextMessage).Text("Invalid"))
+	ds.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{
+		Code:    "min_length",
+		Params:  map[string]any{"min": 8},
+		Message: new(messages.TextMessage).Text("Too short"),
+	})
+
+	var data, err = xml.Marshal(ds)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ds_ = new(Details).Order(KeyOrderInsertion)
+
+	if err = xml.Unmarshal(data, ds_); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "Case 1", got: ds_.Peek("trace"), want: "abc"},
+		{name: "Case 2", got: ds_.Peek("attempts"), want: []any{"1", "2"}},
+		{name: "Case 3", got: ds_.Peek("db"), want: map[string]any{"host": "localhost", "port": "5432"}},
+		{name: "Case 4", got: ds_.PeekFieldMessage("user.items[3]"), want: types.DetailsFieldMessage(new(messages.TextMessage).Text("Invalid"))},
+		{
+			name: "Case 5",
+			got:  ds_.PeekFieldViolations("password"),
+			want: []types.DetailsFieldViolation{
+				{
+					Code:    "min_length",
+					Params:  map[string]any{"min": "8"},
+					Message: new(messages.TextMessage).Text("Too short"),
+				},
+			},
+		},
+		{name: "Case 6", got: ds_.Peek("fields"), want: nil},
+		{name: "Case 7", got: ds_.Peek("violations"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("UnmarshalXML() = %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Повторная упаковка совпадает с исходным документом.
+	var again []byte
+
+	if again, err = xml.Marshal(ds_); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(data) {
+		t.Errorf("MarshalXML() after UnmarshalXML() = %s, want %s", again, data)
+	}
+}
+
+func TestDetails_UnmarshalXML_Replace(t *testing.T) {
+	var ds = new(Details)
+
+	ds.Set("old", "value").SetField(new(FieldKey).Add("old"), new(messages.TextMessage).Text("Old"))
+
+	if err := xml.Unmarshal([]byte(`<Details></Details>`), ds); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ds.Peek("old"); got != nil {
+		t.Errorf("UnmarshalXML() value = %v, want nil", got)
+	}
+
+	if got := ds.Fields(); len(got) != 0 {
+		t.Errorf("UnmarshalXML() fields = %v, want empty", got)
+	}
+
+	if err := xml.Unmarshal([]byte(`<Details><Item key="a">1`), ds); err == nil {
+		t.Error("UnmarshalXML() error = nil, want error")
+	}
+}
+
+func TestDetails_ReservedKeys(t *testing.T) {
+	var ds = new(Details).Order(KeyOrderInsertion)
+
+	ds.Set("fields", "value").Set("_violations", 1).Set("user_id", 7)
+	ds.SetField(new(FieldKey).Add("name"), new(messages.TextMessage).Text("Required"))
+
+	var data, err = json.Marshal(ds)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"_fields":"value","__violations":1,"user_id":7,"fields":{"name":"Required"}}`
+
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+
+	if data, err = xml.Marshal(ds); err != nil {
+		t.Fatal(err)
+	}
+
+	var ds_ = new(Details)
+
+	if err = xml.Unmarshal(data, ds_); err != nil {
+		t.Fatal(err)
+	}
+
+	var wantValues = map[string]any{"fields": "value", "_violations": "1", "user_id": "7"}
+
+	if got, _ := storageSnapshot(ds_); !reflect.DeepEqual(got, wantValues) {
+		t.Errorf("UnmarshalXML() values = %v, want %v", got, wantValues)
+	}
+
+	if got := ds_.PeekFieldMessage("name"); got == nil || got.String() != "Required" {
+		t.Errorf("UnmarshalXML() field message = %v, want %v", got, "Required")
+	}
+}
+
+func TestDetails_UnmarshalXML_LegacyReservedKeys(t *testing.T) {
+	var data = `<Details><Item key="fields">user</Item><Item key="violations">2</Item>` +
+		`<Item key="fields"><Field key="name">Required</Field></Item></Details>`
+
+	var ds = new(Details)
+
+	if err := xml.Unmarshal([]byte(data), ds); err != nil {
+		t.Fatal(err)
+	}
+
+	var wantValues = map[string]any{"fields": "user", "violations": "2"}
+
+	if got, _ := storageSnapshot(ds); !reflect.DeepEqual(got, wantValues) {
+		t.Errorf("UnmarshalXML() values = %v, want %v", got, wantValues)
+	}
+
+	if got := ds.PeekFieldMessage("name"); got == nil || got.String() != "Required" {
+		t.Errorf("UnmarshalXML() field message = %v, want %v", got, "Required")
+	}
+}
+
+func TestEscapeKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "Case 1", key: "fields", want: "_fields"},
+		{name: "Case 2", key: "violations", want: "_violations"},
+		{name: "Case 3", key: "__fields", want: "___fields"},
+		{name: "Case 4", key: "user_id", want: "user_id"},
+		{name: "Case 5", key: "_user", want: "_user"},
+		{name: "Case 6", key: "Fields", want: "Fields"},
+		{name: "Case 7", key: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got = EscapeKey(tt.key)
+
+			if got != tt.want {
+				t.Errorf("EscapeKey() = %v, want %v", got, tt.want)
+			}
+
+			if k := UnescapeKey(got); k != tt.key {
+				t.Errorf("UnescapeKey() = %v, want %v", k, tt.key)
+			}
+		})
+	}
+}
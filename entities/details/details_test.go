@@ -4,7 +4,6 @@ import (
 	"reflect"
 	"sm-errors/entities/messages"
 	"sm-errors/types"
-	"sync"
 	"testing"
 )
 
@@ -12,7 +11,6 @@ func TestDetails_init(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -33,7 +31,6 @@ func TestDetails_init(t *testing.T) {
 					"test1": "1",
 					"test2": false,
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -46,7 +43,6 @@ func TestDetails_init(t *testing.T) {
 					"test1": "1",
 					"test2": false,
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -59,7 +55,6 @@ func TestDetails_init(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -69,7 +64,6 @@ func TestDetails_init(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -82,7 +76,6 @@ func TestDetails_init(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -92,7 +85,6 @@ func TestDetails_init(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 	}
@@ -102,7 +94,6 @@ func TestDetails_init(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if ds.init(); !reflect.DeepEqual(ds, tt.want) {
@@ -116,7 +107,6 @@ func TestDetails_Peek(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	type args struct {
@@ -142,7 +132,6 @@ func TestDetails_Peek(t *testing.T) {
 					"test1": "1",
 					"test2": false,
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -162,7 +151,6 @@ func TestDetails_Peek(t *testing.T) {
 					"test1": "1",
 					"test2": false,
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				k: "test2",
@@ -179,7 +167,6 @@ func TestDetails_Peek(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test",
@@ -196,7 +183,6 @@ func TestDetails_Peek(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test",
@@ -210,7 +196,6 @@ func TestDetails_Peek(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if gotV := ds.Peek(tt.args.k); !reflect.DeepEqual(gotV, tt.wantV) {
@@ -224,7 +209,6 @@ func TestDetails_Set(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	type args struct {
@@ -251,7 +235,6 @@ func TestDetails_Set(t *testing.T) {
 					"test1": "1",
 					"test2": false,
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				k: "test3",
@@ -269,7 +252,7 @@ func TestDetails_Set(t *testing.T) {
 					"test2": false,
 					"test3": "3",
 				},
-				rwMux: new(sync.RWMutex),
+				keys: []string{"test3"},
 			},
 		},
 		{
@@ -282,7 +265,6 @@ func TestDetails_Set(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -298,7 +280,7 @@ func TestDetails_Set(t *testing.T) {
 				storage: map[string]any{
 					"test1": "1",
 				},
-				rwMux: new(sync.RWMutex),
+				keys: []string{"test1"},
 			},
 		},
 		{
@@ -311,7 +293,6 @@ func TestDetails_Set(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -327,7 +308,7 @@ func TestDetails_Set(t *testing.T) {
 				storage: map[string]any{
 					"test1": "1",
 				},
-				rwMux: new(sync.RWMutex),
+				keys: []string{"test1"},
 			},
 		},
 	}
@@ -337,7 +318,6 @@ func TestDetails_Set(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.Set(tt.args.k, tt.args.v); !reflect.DeepEqual(got, tt.want) {
@@ -351,7 +331,6 @@ func TestDetails_Reset(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -373,7 +352,6 @@ func TestDetails_Reset(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -387,7 +365,6 @@ func TestDetails_Reset(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -395,12 +372,10 @@ func TestDetails_Reset(t *testing.T) {
 			fields: fields{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -419,7 +394,6 @@ func TestDetails_Reset(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -433,7 +407,6 @@ func TestDetails_Reset(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -444,12 +417,10 @@ func TestDetails_Reset(t *testing.T) {
 					"test": true,
 					"key":  "value",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 	}
@@ -459,7 +430,6 @@ func TestDetails_Reset(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.Reset(); !reflect.DeepEqual(got, tt.want) {
@@ -473,7 +443,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	type args struct {
@@ -500,7 +469,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -523,7 +491,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 				storage: map[string]any{
 					"test1": "312",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -535,7 +502,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 			fields: fields{
 				fields:  Fields{},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -547,7 +513,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test1",
@@ -568,7 +533,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: "test3",
@@ -582,7 +546,6 @@ func TestDetails_PeekFieldMessage(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if gotM := ds.PeekFieldMessage(tt.args.k); !reflect.DeepEqual(gotM, tt.wantM) {
@@ -596,7 +559,6 @@ func TestDetails_SetField(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	type args struct {
@@ -622,7 +584,6 @@ func TestDetails_SetField(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				k: new(FieldKey).Add("test2"),
@@ -642,7 +603,6 @@ func TestDetails_SetField(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -655,7 +615,6 @@ func TestDetails_SetField(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: new(FieldKey).Add("test2"),
@@ -673,7 +632,6 @@ func TestDetails_SetField(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -681,7 +639,6 @@ func TestDetails_SetField(t *testing.T) {
 			fields: fields{
 				fields:  Fields{},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: new(FieldKey).Add("test"),
@@ -695,7 +652,6 @@ func TestDetails_SetField(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -703,7 +659,6 @@ func TestDetails_SetField(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				k: new(FieldKey).Add("test"),
@@ -717,7 +672,31 @@ func TestDetails_SetField(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
+			},
+		},
+		{
+			name: "Case 5",
+			fields: fields{
+				fields: Fields{
+					{
+						Key:     new(FieldKey).Add("user").AddArray("items", 1),
+						Message: new(messages.TextMessage).Text("123"),
+					},
+				},
+				storage: nil,
+			},
+			args: args{
+				k: new(FieldKey).Add("user").AddArray("items", 1),
+				m: new(messages.TextMessage).Text("321"),
+			},
+			want: &Details{
+				fields: Fields{
+					{
+						Key:     new(FieldKey).Add("user").AddArray("items", 1),
+						Message: new(messages.TextMessage).Text("321"),
+					},
+				},
+				storage: map[string]any{},
 			},
 		},
 	}
@@ -727,7 +706,6 @@ func TestDetails_SetField(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.SetField(tt.args.k, tt.args.m); !reflect.DeepEqual(got, tt.want) {
@@ -741,7 +719,6 @@ func TestDetails_SetFields(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	type args struct {
@@ -766,7 +743,6 @@ func TestDetails_SetFields(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			args: args{
 				fields: []types.DetailsField{
@@ -790,7 +766,6 @@ func TestDetails_SetFields(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -803,7 +778,6 @@ func TestDetails_SetFields(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				fields: []types.DetailsField{
@@ -825,7 +799,6 @@ func TestDetails_SetFields(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -833,7 +806,6 @@ func TestDetails_SetFields(t *testing.T) {
 			fields: fields{
 				fields:  Fields{},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				fields: []types.DetailsField{
@@ -851,7 +823,6 @@ func TestDetails_SetFields(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -859,7 +830,6 @@ func TestDetails_SetFields(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				fields: []types.DetailsField{
@@ -877,7 +847,6 @@ func TestDetails_SetFields(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -885,7 +854,6 @@ func TestDetails_SetFields(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			args: args{
 				fields: []types.DetailsField{
@@ -911,7 +879,6 @@ func TestDetails_SetFields(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 	}
@@ -921,7 +888,6 @@ func TestDetails_SetFields(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.SetFields(tt.args.fields...); !reflect.DeepEqual(got, tt.want) {
@@ -935,7 +901,6 @@ func TestDetails_ResetFields(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -957,12 +922,10 @@ func TestDetails_ResetFields(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -970,12 +933,10 @@ func TestDetails_ResetFields(t *testing.T) {
 			fields: fields{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -994,14 +955,12 @@ func TestDetails_ResetFields(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{},
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -1011,14 +970,12 @@ func TestDetails_ResetFields(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{},
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 	}
@@ -1028,7 +985,6 @@ func TestDetails_ResetFields(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.ResetFields(); !reflect.DeepEqual(got, tt.want) {
@@ -1042,7 +998,6 @@ func TestDetails_Clone(t *testing.T) {
 	type fields struct {
 		fields  Fields
 		storage map[string]any
-		rwMux   *sync.RWMutex
 	}
 
 	tests := []struct {
@@ -1062,7 +1017,6 @@ func TestDetails_Clone(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -1074,7 +1028,6 @@ func TestDetails_Clone(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -1084,14 +1037,12 @@ func TestDetails_Clone(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{},
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -1104,7 +1055,6 @@ func TestDetails_Clone(t *testing.T) {
 					},
 				},
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields: Fields{
@@ -1114,7 +1064,6 @@ func TestDetails_Clone(t *testing.T) {
 					},
 				},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 		{
@@ -1129,7 +1078,6 @@ func TestDetails_Clone(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: nil,
 			},
 			want: &Details{
 				fields: Fields{
@@ -1141,7 +1089,6 @@ func TestDetails_Clone(t *testing.T) {
 				storage: map[string]any{
 					"test": "123",
 				},
-				rwMux: new(sync.RWMutex),
 			},
 		},
 		{
@@ -1149,12 +1096,10 @@ func TestDetails_Clone(t *testing.T) {
 			fields: fields{
 				fields:  nil,
 				storage: nil,
-				rwMux:   new(sync.RWMutex),
 			},
 			want: &Details{
 				fields:  Fields{},
 				storage: map[string]any{},
-				rwMux:   new(sync.RWMutex),
 			},
 		},
 	}
@@ -1164,7 +1109,6 @@ func TestDetails_Clone(t *testing.T) {
 			ds := &Details{
 				fields:  tt.fields.fields,
 				storage: tt.fields.storage,
-				rwMux:   tt.fields.rwMux,
 			}
 
 			if got := ds.Clone(); !reflect.DeepEqual(got, tt.want) {
@@ -1173,3 +1117,29 @@ func TestDetails_Clone(t *testing.T) {
 		})
 	}
 }
+
+func TestDetails_Fields(t *testing.T) {
+	var (
+		key     = new(FieldKey).Add("test")
+		message = new(messages.TextMessage).Text("123")
+		ds      = new(Details)
+	)
+
+	if got := ds.Fields(); len(got) != 0 {
+		t.Errorf("Fields() = %v, want empty", got)
+	}
+
+	ds.SetField(key, message)
+
+	var got = ds.Fields()
+
+	if !reflect.DeepEqual(got, []types.DetailsField{{Key: key, Message: message}}) {
+		t.Errorf("Fields() = %v, want %v", got, []types.DetailsField{{Key: key, Message: message}})
+	}
+
+	got[0].Message = nil
+
+	if ds.PeekFieldMessage("test") == nil {
+		t.Errorf("Fields() returned internal storage")
+	}
+}
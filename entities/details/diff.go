@@ -0,0 +1,173 @@
+package details
+
+import (
+	"fmt"
+	"reflect"
+	"sm-errors/types"
+	"sort"
+	"strings"
+)
+
+type (
+	// DetailsDiff - различия между деталями ошибки.
+	// Ключи в каждом списке отсортированы.
+	DetailsDiff struct {
+		Added   []ValueDiff
+		Removed []ValueDiff
+		Changed []ValueDiff
+
+		AddedFields   []FieldDiff
+		RemovedFields []FieldDiff
+		ChangedFields []FieldDiff
+	}
+
+	// ValueDiff - различие значения хранилища деталей.
+	// Для добавленного значения Old равно nil, для удаленного - New.
+	ValueDiff struct {
+		Key string
+		Old any
+		New any
+	}
+
+	// FieldDiff - различие поля деталей.
+	// Для добавленного поля Old равно nil, для удаленного - New.
+	FieldDiff struct {
+		Key string
+		Old *types.DetailsField
+		New *types.DetailsField
+	}
+)
+
+// Diff - получение различий между деталями from и to.
+// Значения хранилища сравниваются через reflect.DeepEqual и доступны только у деталей типа *Details,
+// поля сравниваются по ключам, тексту сообщений, кодам и параметрам нарушений.
+func Diff(from, to types.Details) (d *DetailsDiff) {
+	d = new(DetailsDiff)
+
+	// storage
+	{
+		var (
+			a, _ = storageSnapshot(from)
+			b, _ = storageSnapshot(to)
+		)
+
+		for k, v := range b {
+			if ov, ok := a[k]; !ok {
+				d.Added = append(d.Added, ValueDiff{Key: k, New: v})
+			} else if !reflect.DeepEqual(ov, v) {
+				d.Changed = append(d.Changed, ValueDiff{Key: k, Old: ov, New: v})
+			}
+		}
+
+		for k, v := range a {
+			if _, ok := b[k]; !ok {
+				d.Removed = append(d.Removed, ValueDiff{Key: k, Old: v})
+			}
+		}
+	}
+
+	// fields
+	{
+		var (
+			a = fieldsByKey(from)
+			b = fieldsByKey(to)
+		)
+
+		for k, f := range b {
+			if of, ok := a[k]; !ok {
+				d.AddedFields = append(d.AddedFields, FieldDiff{Key: k, New: f})
+			} else if !equalFields(of, f) {
+				d.ChangedFields = append(d.ChangedFields, FieldDiff{Key: k, Old: of, New: f})
+			}
+		}
+
+		for k, f := range a {
+			if _, ok := b[k]; !ok {
+				d.RemovedFields = append(d.RemovedFields, FieldDiff{Key: k, Old: f})
+			}
+		}
+	}
+
+	for _, list := range [][]ValueDiff{d.Added, d.Removed, d.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+
+	for _, list := range [][]FieldDiff{d.AddedFields, d.RemovedFields, d.ChangedFields} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+
+	return
+}
+
+// fieldsByKey - получение полей деталей по строковому ключу.
+func fieldsByKey(d types.Details) (fields map[string]*types.DetailsField) {
+	fields = make(map[string]*types.DetailsField)
+
+	if d == nil {
+		return
+	}
+
+	for _, f := range d.Fields() {
+		fields[f.Key.String()] = &f
+	}
+
+	return
+}
+
+// Empty - проверка отсутствия различий.
+func (d *DetailsDiff) Empty() (ok bool) {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.AddedFields) == 0 && len(d.RemovedFields) == 0 && len(d.ChangedFields) == 0
+}
+
+// String - получение текстового представления различий для журналов.
+// Добавления отмечаются "+", удаления - "-", изменения - "~":
+//
+//	~ attempt: 1 -> 2
+//	+ field user.name: Value is required
+func (d *DetailsDiff) String() (str string) {
+	var b strings.Builder
+
+	for _, v := range d.Added {
+		fmt.Fprintf(&b, "+ %s: %v\n", v.Key, v.New)
+	}
+
+	for _, v := range d.Removed {
+		fmt.Fprintf(&b, "- %s: %v\n", v.Key, v.Old)
+	}
+
+	for _, v := range d.Changed {
+		fmt.Fprintf(&b, "~ %s: %v -> %v\n", v.Key, v.Old, v.New)
+	}
+
+	for _, f := range d.AddedFields {
+		fmt.Fprintf(&b, "+ field %s: %s\n", f.Key, fieldText(f.New))
+	}
+
+	for _, f := range d.RemovedFields {
+		fmt.Fprintf(&b, "- field %s: %s\n", f.Key, fieldText(f.Old))
+	}
+
+	for _, f := range d.ChangedFields {
+		fmt.Fprintf(&b, "~ field %s: %s -> %s\n", f.Key, fieldText(f.Old), fieldText(f.New))
+	}
+
+	return b.String()
+}
+
+// fieldText - получение текста поля с кодами нарушений.
+func fieldText(f *types.DetailsField) (text string) {
+	text = messageText(f.Message)
+
+	if len(f.Violations) == 0 {
+		return
+	}
+
+	var codes = make([]string, 0, len(f.Violations))
+
+	for _, v := range f.Violations {
+		codes = append(codes, v.Code)
+	}
+
+	return fmt.Sprintf("%s [%s]", text, strings.Join(codes, ", "))
+}
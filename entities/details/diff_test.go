@@ -0,0 +1,104 @@
+package details
+
+import (
+	"reflect"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var (
+		from = newMergeDetails(map[string]any{"a": 1, "b": 2, "c": []int{1}}, map[string]string{"name": "Old", "email": "Invalid"})
+		to   = newMergeDetails(map[string]any{"b": 3, "c": []int{1}, "d": 4}, map[string]string{"name": "New", "login": "Taken"})
+		d    = Diff(from, to)
+	)
+
+	var keys = func(list []ValueDiff) (keys []string) {
+		for _, v := range list {
+			keys = append(keys, v.Key)
+		}
+
+		return
+	}
+
+	var fieldKeys = func(list []FieldDiff) (keys []string) {
+		for _, f := range list {
+			keys = append(keys, f.Key)
+		}
+
+		return
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "Case 1", got: keys(d.Added), want: []string{"d"}},
+		{name: "Case 2", got: keys(d.Removed), want: []string{"a"}},
+		{name: "Case 3", got: keys(d.Changed), want: []string{"b"}},
+		{name: "Case 4", got: fieldKeys(d.AddedFields), want: []string{"login"}},
+		{name: "Case 5", got: fieldKeys(d.RemovedFields), want: []string{"email"}},
+		{name: "Case 6", got: fieldKeys(d.ChangedFields), want: []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("Diff() keys = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if want := (ValueDiff{Key: "b", Old: 2, New: 3}); !reflect.DeepEqual(d.Changed[0], want) {
+		t.Errorf("Diff() changed = %v, want %v", d.Changed[0], want)
+	}
+
+	if d.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+}
+
+func TestDiff_Empty(t *testing.T) {
+	var (
+		a = newViolationsDetails()
+		b = a.Clone()
+	)
+
+	if d := Diff(a, b); !d.Empty() {
+		t.Errorf("Diff() = %v, want empty", d)
+	}
+
+	if d := Diff(nil, nil); !d.Empty() {
+		t.Errorf("Diff() = %v, want empty", d)
+	}
+
+	// Изменение параметров нарушения является изменением поля.
+	b.(*Details).AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{Code: "required"})
+
+	if d := Diff(a, b); len(d.ChangedFields) != 1 {
+		t.Errorf("Diff() changed fields = %v, want 1", d.ChangedFields)
+	}
+}
+
+func TestDetailsDiff_String(t *testing.T) {
+	var (
+		from = newMergeDetails(map[string]any{"attempt": 1, "trace": "x"}, nil)
+		to   = newMergeDetails(map[string]any{"attempt": 2, "host": "db"}, nil)
+	)
+
+	to.AddFieldViolation(new(FieldKey).Add("user").Add("name"), types.DetailsFieldViolation{
+		Code:    "required",
+		Message: new(messages.TextMessage).Text("Value is required"),
+	})
+
+	var want = "+ host: db\n" +
+		"- trace: x\n" +
+		"~ attempt: 1 -> 2\n" +
+		"+ field user.name: Value is required [required]\n"
+
+	if got := Diff(from, to).String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
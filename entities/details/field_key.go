@@ -1,52 +1,310 @@
 package details
 
 import (
+	"errors"
 	"fmt"
 	"sm-errors/types"
+	"strconv"
 	"strings"
 )
 
+// FieldKeySegmentKind - тип сегмента пути к ключу поля.
+type FieldKeySegmentKind uint8
+
+// Типы сегментов пути к ключу поля.
+const (
+	// FieldKeyName - имя поля, в строке отделяется точкой: "user.name".
+	FieldKeyName FieldKeySegmentKind = iota
+	// FieldKeyIndex - индекс элемента массива: "items[3]".
+	FieldKeyIndex
+	// FieldKeyMap - ключ элемента карты: "labels[env]".
+	FieldKeyMap
+)
+
+// ErrInvalidFieldKey - ошибка разбора строки ключа поля.
+var ErrInvalidFieldKey = errors.New("details: invalid field key")
+
 type (
 	// FieldKey - ключ поля.
 	FieldKey struct {
-		path []string
+		path []FieldKeySegment
+	}
+
+	// FieldKeySegment - сегмент пути к ключу поля.
+	// Для FieldKeyName и FieldKeyMap значение хранится в Name, для FieldKeyIndex - в Index.
+	FieldKeySegment struct {
+		Kind  FieldKeySegmentKind
+		Name  string
+		Index int
 	}
 )
 
+// ParseFieldKey - разбор строки ключа поля, обратный FieldKey.String.
+// Имена разделяются точками, индексы массивов и ключи карт указываются в квадратных скобках.
+// Точки, скобки и обратная косая черта внутри имен и ключей карт экранируются
+// обратной косой чертой, например "labels[a\.b]".
+func ParseFieldKey(str string) (fk *FieldKey, err error) {
+	fk = new(FieldKey)
+	fk.init()
+
+	var (
+		runes = []rune(str)
+		pos   int
+	)
+
+	for pos < len(runes) {
+		// Имя поля.
+		{
+			var (
+				name   string
+				dotted = pos > 0
+			)
+
+			if name, pos, err = readFieldKeyPart(runes, pos, false); err != nil {
+				return nil, fmt.Errorf("%w '%s': %w", ErrInvalidFieldKey, str, err)
+			}
+
+			if name != "" || dotted || pos == len(runes) || runes[pos] == '.' {
+				fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: name})
+			}
+		}
+
+		// Индексы массивов и ключи карт.
+		for pos < len(runes) && runes[pos] == '[' {
+			var (
+				key     string
+				escaped bool
+			)
+
+			escaped = pos+1 < len(runes) && runes[pos+1] == '\\'
+
+			if key, pos, err = readFieldKeyPart(runes, pos+1, true); err != nil {
+				return nil, fmt.Errorf("%w '%s': %w", ErrInvalidFieldKey, str, err)
+			}
+
+			if pos == len(runes) {
+				return nil, fmt.Errorf("%w '%s': unterminated '['", ErrInvalidFieldKey, str)
+			}
+
+			pos++
+
+			if index, ok := parseFieldKeyIndex(key); ok && !escaped {
+				fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyIndex, Index: index})
+			} else {
+				fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyMap, Name: key})
+			}
+		}
+
+		if pos == len(runes) {
+			break
+		}
+
+		if runes[pos] != '.' {
+			return nil, fmt.Errorf("%w '%s': unexpected '%c' at %d", ErrInvalidFieldKey, str, runes[pos], pos)
+		}
+
+		if pos++; pos == len(runes) {
+			fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName})
+		}
+	}
+
+	return
+}
+
+// MustParseFieldKey - разбор строки ключа поля с паникой в случае ошибки.
+func MustParseFieldKey(str string) (fk *FieldKey) {
+	var err error
+
+	if fk, err = ParseFieldKey(str); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// readFieldKeyPart - чтение имени или ключа карты до неэкранированного разделителя.
+func readFieldKeyPart(runes []rune, pos int, bracket bool) (part string, next int, err error) {
+	var b strings.Builder
+
+	for next = pos; next < len(runes); next++ {
+		var r = runes[next]
+
+		switch {
+		case r == '\\':
+			{
+				if next++; next == len(runes) {
+					return "", next, errors.New("trailing '\\'")
+				}
+
+				b.WriteRune(runes[next])
+				continue
+			}
+		case bracket && r == ']':
+			return b.String(), next, nil
+		case bracket && r == '[':
+			return "", next, fmt.Errorf("unexpected '[' at %d", next)
+		case !bracket && (r == '.' || r == '['):
+			return b.String(), next, nil
+		case !bracket && r == ']':
+			return "", next, fmt.Errorf("unexpected ']' at %d", next)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String(), next, nil
+}
+
+// parseFieldKeyIndex - разбор индекса массива в каноничной десятичной записи.
+func parseFieldKeyIndex(str string) (index int, ok bool) {
+	var err error
+
+	if index, err = strconv.Atoi(str); err != nil || index < 0 || strconv.Itoa(index) != str {
+		return 0, false
+	}
+
+	return index, true
+}
+
 // init - инициализация ключа поля.
 func (fk *FieldKey) init() {
 	if fk.path == nil {
-		fk.path = make([]string, 0)
+		fk.path = make([]FieldKeySegment, 0)
 	}
 }
 
 // Add - добавления элемента в путь к ключу.
+// Каждый элемент добавляется как имя поля, точки и скобки в нем экранируются при выводе.
 func (fk *FieldKey) Add(path ...string) types.DetailsFieldKey {
 	fk.init()
 
-	fk.path = append(fk.path, path...)
+	for _, name := range path {
+		fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: name})
+	}
+
 	return fk
 }
 
 // AddArray - добавления массива в путь к ключу.
+// Пустое имя добавляет только индекс, например для вложенных массивов.
 func (fk *FieldKey) AddArray(name string, index int) types.DetailsFieldKey {
 	fk.init()
 
-	fk.path = append(fk.path, fmt.Sprintf("%s[%d]", name, index))
+	if name != "" {
+		fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: name})
+	}
+
+	fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyIndex, Index: index})
 	return fk
 }
 
 // AddMap - добавления карты в путь к ключу.
+// Пустое имя добавляет только ключ карты.
 func (fk *FieldKey) AddMap(name string, key any) types.DetailsFieldKey {
 	fk.init()
 
-	fk.path = append(fk.path, fmt.Sprintf("%s[%v]", name, key))
+	if name != "" {
+		fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: name})
+	}
+
+	fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyMap, Name: fmt.Sprint(key)})
 	return fk
 }
 
+// Segments - получение копии сегментов пути к ключу.
+func (fk *FieldKey) Segments() (segments []FieldKeySegment) {
+	segments = make([]FieldKeySegment, len(fk.path))
+	copy(segments, fk.path)
+
+	return
+}
+
+// Equal - сравнение ключей по значению.
+// Имена полей и ключи карт с одинаковым значением не различаются: они указывают на один член объекта
+// при упаковке и не различаются в записях JSON Pointer и JSONPath.
+// Ключи других реализаций сравниваются по строковому представлению.
+func (fk *FieldKey) Equal(k types.DetailsFieldKey) (ok bool) {
+	if k == nil {
+		return false
+	}
+
+	var other, isFieldKey = k.(*FieldKey)
+
+	if !isFieldKey {
+		return fk.String() == k.String()
+	}
+
+	if other == nil || len(fk.path) != len(other.path) {
+		return false
+	}
+
+	for i := range fk.path {
+		if !fk.path[i].equal(other.path[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equal - сравнение сегментов пути к ключу, см. FieldKey.Equal.
+func (s FieldKeySegment) equal(other FieldKeySegment) (ok bool) {
+	if s.Kind == FieldKeyIndex || other.Kind == FieldKeyIndex {
+		return s.Kind == other.Kind && s.Index == other.Index
+	}
+
+	return s.Name == other.Name
+}
+
 // String - получение строкового представления путя к ключу.
 func (fk *FieldKey) String() (str string) {
-	return strings.Join(fk.path, ".")
+	var b strings.Builder
+
+	for i, s := range fk.path {
+		switch s.Kind {
+		case FieldKeyName:
+			{
+				if i > 0 {
+					b.WriteByte('.')
+				}
+
+				writeFieldKeyEscaped(&b, s.Name, ".[]")
+			}
+		case FieldKeyIndex:
+			{
+				b.WriteByte('[')
+				b.WriteString(strconv.Itoa(s.Index))
+				b.WriteByte(']')
+			}
+		case FieldKeyMap:
+			{
+				b.WriteByte('[')
+
+				// Ключ, совпадающий с записью индекса, экранируется, чтобы не стать индексом при разборе.
+				if _, ok := parseFieldKeyIndex(s.Name); ok {
+					b.WriteByte('\\')
+				}
+
+				writeFieldKeyEscaped(&b, s.Name, "[]")
+				b.WriteByte(']')
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// writeFieldKeyEscaped - запись имени с экранированием специальных символов.
+func writeFieldKeyEscaped(b *strings.Builder, str, special string) {
+	for _, r := range str {
+		if r == '\\' || strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return
 }
 
 // Clone - копирование ключа.
@@ -56,9 +314,25 @@ func (fk *FieldKey) Clone() types.DetailsFieldKey {
 	var fk_ = new(FieldKey)
 	fk_.init()
 
-	for _, p := range fk.path {
-		fk_.path = append(fk_.path, strings.Clone(p))
+	for _, s := range fk.path {
+		s.Name = strings.Clone(s.Name)
+		fk_.path = append(fk_.path, s)
 	}
 
 	return fk_
 }
+
+// equalFieldKeys - сравнение ключей полей по значению.
+func equalFieldKeys(a, b types.DetailsFieldKey) (ok bool) {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if e, isEqualer := a.(interface {
+		Equal(k types.DetailsFieldKey) bool
+	}); isEqualer {
+		return e.Equal(b)
+	}
+
+	return a.String() == b.String()
+}
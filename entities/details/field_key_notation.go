@@ -0,0 +1,445 @@
+package details
+
+import (
+	"errors"
+	"fmt"
+	"sm-errors/types"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// FieldKeyNotation - запись ключей полей при упаковке деталей ошибки.
+type FieldKeyNotation uint8
+
+// Записи ключей полей.
+const (
+	// FieldKeyNotationDefault - запись, установленная по умолчанию, см. SetDefaultFieldKeyNotation.
+	FieldKeyNotationDefault FieldKeyNotation = iota
+	// FieldKeyNotationDot - запись через точку: "user.items[3].name".
+	FieldKeyNotationDot
+	// FieldKeyNotationJSONPointer - запись JSON Pointer (RFC 6901): "/user/items/3/name".
+	FieldKeyNotationJSONPointer
+	// FieldKeyNotationJSONPath - запись JSONPath (RFC 9535): "$.user.items[3].name".
+	FieldKeyNotationJSONPath
+)
+
+// defaultFieldKeyNotation - запись ключей полей по умолчанию.
+var defaultFieldKeyNotation = struct {
+	notation FieldKeyNotation
+	rwMux    *sync.RWMutex
+}{
+	notation: FieldKeyNotationDot,
+	rwMux:    new(sync.RWMutex),
+}
+
+// SetDefaultFieldKeyNotation - установить запись ключей полей по умолчанию.
+// Значение FieldKeyNotationDefault возвращает запись через точку.
+func SetDefaultFieldKeyNotation(n FieldKeyNotation) {
+	if n == FieldKeyNotationDefault {
+		n = FieldKeyNotationDot
+	}
+
+	defaultFieldKeyNotation.rwMux.Lock()
+	defer defaultFieldKeyNotation.rwMux.Unlock()
+
+	defaultFieldKeyNotation.notation = n
+}
+
+// DefaultFieldKeyNotation - получение записи ключей полей по умолчанию.
+func DefaultFieldKeyNotation() (n FieldKeyNotation) {
+	defaultFieldKeyNotation.rwMux.RLock()
+	defer defaultFieldKeyNotation.rwMux.RUnlock()
+
+	return defaultFieldKeyNotation.notation
+}
+
+// String - получение названия записи.
+func (n FieldKeyNotation) String() (str string) {
+	switch n {
+	case FieldKeyNotationDefault:
+		return "default"
+	case FieldKeyNotationDot:
+		return "dot"
+	case FieldKeyNotationJSONPointer:
+		return "json-pointer"
+	case FieldKeyNotationJSONPath:
+		return "json-path"
+	}
+
+	return fmt.Sprintf("FieldKeyNotation(%d)", uint8(n))
+}
+
+// FormatFieldKey - получение строки ключа поля в указанной записи.
+func FormatFieldKey(k types.DetailsFieldKey, n FieldKeyNotation) (str string) {
+	if k == nil {
+		return
+	}
+
+	if n == FieldKeyNotationDefault {
+		n = DefaultFieldKeyNotation()
+	}
+
+	switch n {
+	case FieldKeyNotationJSONPointer:
+		return k.JSONPointer()
+	case FieldKeyNotationJSONPath:
+		return k.JSONPath()
+	}
+
+	return k.String()
+}
+
+// ParseFieldKeyNotation - разбор строки ключа поля в указанной записи.
+// Для FieldKeyNotationDefault запись определяется по первому символу:
+// "/" - JSON Pointer, "$" - JSONPath, иначе запись через точку.
+func ParseFieldKeyNotation(str string, n FieldKeyNotation) (fk *FieldKey, err error) {
+	if n == FieldKeyNotationDefault {
+		switch {
+		case strings.HasPrefix(str, "/"):
+			n = FieldKeyNotationJSONPointer
+		case str == "$" || strings.HasPrefix(str, "$.") || strings.HasPrefix(str, "$["):
+			n = FieldKeyNotationJSONPath
+		}
+	}
+
+	switch n {
+	case FieldKeyNotationJSONPointer:
+		return ParseJSONPointer(str)
+	case FieldKeyNotationJSONPath:
+		return ParseJSONPath(str)
+	}
+
+	return ParseFieldKey(str)
+}
+
+// JSONPointer - получение ключа в записи JSON Pointer (RFC 6901).
+// Имена полей и ключи карт записываются одинаково, символы "~" и "/" экранируются как "~0" и "~1".
+func (fk *FieldKey) JSONPointer() (str string) {
+	var b strings.Builder
+
+	for _, s := range fk.path {
+		b.WriteByte('/')
+
+		if s.Kind == FieldKeyIndex {
+			b.WriteString(strconv.Itoa(s.Index))
+			continue
+		}
+
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s.Name))
+	}
+
+	return b.String()
+}
+
+// JSONPath - получение ключа в записи JSONPath (RFC 9535).
+// Имена полей и ключи карт записываются одинаково: идентификаторы - через точку,
+// остальные - в скобках в одинарных кавычках: "$.labels['a.b']".
+func (fk *FieldKey) JSONPath() (str string) {
+	var b strings.Builder
+
+	b.WriteByte('$')
+
+	for _, s := range fk.path {
+		switch {
+		case s.Kind == FieldKeyIndex:
+			{
+				b.WriteByte('[')
+				b.WriteString(strconv.Itoa(s.Index))
+				b.WriteByte(']')
+			}
+		case isJSONPathIdentifier(s.Name):
+			{
+				b.WriteByte('.')
+				b.WriteString(s.Name)
+			}
+		default:
+			{
+				b.WriteString("['")
+				writeJSONPathEscaped(&b, s.Name)
+				b.WriteString("']")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// writeJSONPathEscaped - запись строки JSONPath в одинарных кавычках с экранированием по RFC 9535.
+func writeJSONPathEscaped(b *strings.Builder, str string) {
+	for _, r := range str {
+		switch r {
+		case '\\', '\'':
+			{
+				b.WriteByte('\\')
+				b.WriteRune(r)
+			}
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			{
+				if r < 0x20 {
+					fmt.Fprintf(b, `\u%04x`, r)
+					continue
+				}
+
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	return
+}
+
+// ParseJSONPointer - разбор ключа поля из записи JSON Pointer (RFC 6901).
+// Сегменты в каноничной десятичной записи становятся индексами массивов, остальные - именами полей.
+func ParseJSONPointer(str string) (fk *FieldKey, err error) {
+	fk = new(FieldKey)
+	fk.init()
+
+	if str == "" {
+		return
+	}
+
+	if !strings.HasPrefix(str, "/") {
+		return nil, fmt.Errorf("%w '%s': json pointer must start with '/'", ErrInvalidFieldKey, str)
+	}
+
+	for _, token := range strings.Split(str[1:], "/") {
+		if index, ok := parseFieldKeyIndex(token); ok {
+			fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyIndex, Index: index})
+			continue
+		}
+
+		for i := 0; i < len(token); i++ {
+			if token[i] == '~' && (i+1 == len(token) || token[i+1] != '0' && token[i+1] != '1') {
+				return nil, fmt.Errorf("%w '%s': invalid escape in json pointer", ErrInvalidFieldKey, str)
+			}
+		}
+
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: token})
+	}
+
+	return
+}
+
+// ParseJSONPath - разбор ключа поля из записи JSONPath (RFC 9535).
+// Поддерживаются обращения к полям через точку и в скобках, а также индексы массивов.
+// Одинарные и двойные кавычки равнозначны, поля в скобках и через точку становятся именами полей.
+func ParseJSONPath(str string) (fk *FieldKey, err error) {
+	fk = new(FieldKey)
+	fk.init()
+
+	if !strings.HasPrefix(str, "$") {
+		return nil, fmt.Errorf("%w '%s': json path must start with '$'", ErrInvalidFieldKey, str)
+	}
+
+	var pos = 1
+
+	for pos < len(str) {
+		switch str[pos] {
+		case '.':
+			{
+				var end = pos + 1
+
+				for end < len(str) && str[end] != '.' && str[end] != '[' {
+					end++
+				}
+
+				if !isJSONPathIdentifier(str[pos+1 : end]) {
+					return nil, fmt.Errorf("%w '%s': invalid name at %d", ErrInvalidFieldKey, str, pos+1)
+				}
+
+				fk.path = append(fk.path, FieldKeySegment{Kind: FieldKeyName, Name: str[pos+1 : end]})
+				pos = end
+			}
+		case '[':
+			{
+				var (
+					segment FieldKeySegment
+					next    int
+				)
+
+				if segment, next, err = readJSONPathBracket(str, pos+1); err != nil {
+					return nil, fmt.Errorf("%w '%s': %w", ErrInvalidFieldKey, str, err)
+				}
+
+				fk.path = append(fk.path, segment)
+				pos = next
+			}
+		default:
+			return nil, fmt.Errorf("%w '%s': unexpected '%c' at %d", ErrInvalidFieldKey, str, str[pos], pos)
+		}
+	}
+
+	return
+}
+
+// readJSONPathBracket - чтение индекса или имени в скобках записи JSONPath.
+func readJSONPathBracket(str string, pos int) (segment FieldKeySegment, next int, err error) {
+	if pos < len(str) && (str[pos] == '\'' || str[pos] == '"') {
+		var name string
+
+		if name, next, err = readJSONPathString(str, pos); err != nil {
+			return
+		}
+
+		if next >= len(str) || str[next] != ']' {
+			return segment, next, fmt.Errorf("expected ']' at %d", next)
+		}
+
+		return FieldKeySegment{Kind: FieldKeyName, Name: name}, next + 1, nil
+	}
+
+	var end = strings.IndexByte(str[pos:], ']')
+
+	if end < 0 {
+		return segment, len(str), fmt.Errorf("unterminated '[' at %d", pos-1)
+	}
+
+	var index, ok = parseFieldKeyIndex(str[pos : pos+end])
+
+	if !ok {
+		return segment, pos, fmt.Errorf("invalid index '%s'", str[pos:pos+end])
+	}
+
+	return FieldKeySegment{Kind: FieldKeyIndex, Index: index}, pos + end + 1, nil
+}
+
+// readJSONPathString - чтение строки JSONPath в кавычках с разбором экранирования по RFC 9535.
+// Позиция next указывает на символ после закрывающей кавычки.
+func readJSONPathString(str string, pos int) (value string, next int, err error) {
+	var (
+		quote = str[pos]
+		b     strings.Builder
+	)
+
+	for next = pos + 1; next < len(str); next++ {
+		var c = str[next]
+
+		switch {
+		case c == quote:
+			return b.String(), next + 1, nil
+		case c < 0x20:
+			return "", next, fmt.Errorf("unescaped control character at %d", next)
+		case c != '\\':
+			{
+				b.WriteByte(c)
+				continue
+			}
+		}
+
+		if next++; next == len(str) {
+			return "", next, errors.New("trailing '\\'")
+		}
+
+		switch c = str[next]; c {
+		case '\\', '/', quote:
+			b.WriteByte(c)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			{
+				var r rune
+
+				if r, next, err = readJSONPathUnicode(str, next+1); err != nil {
+					return "", next, err
+				}
+
+				b.WriteRune(r)
+				next--
+			}
+		default:
+			return "", next, fmt.Errorf("invalid escape '\\%c' at %d", c, next-1)
+		}
+	}
+
+	return "", next, fmt.Errorf("unterminated string at %d", pos)
+}
+
+// readJSONPathUnicode - чтение экранирования "\uXXXX", в том числе суррогатной пары.
+// Позиция pos указывает на первую шестнадцатеричную цифру.
+func readJSONPathUnicode(str string, pos int) (r rune, next int, err error) {
+	if r, err = parseJSONPathHex(str, pos); err != nil {
+		return
+	}
+
+	next = pos + 4
+
+	if !utf16.IsSurrogate(r) {
+		return
+	}
+
+	if r >= 0xdc00 || !strings.HasPrefix(str[next:], `\u`) {
+		return 0, next, fmt.Errorf("invalid surrogate at %d", pos-2)
+	}
+
+	var low rune
+
+	if low, err = parseJSONPathHex(str, next+2); err != nil {
+		return 0, next, err
+	}
+
+	if r = utf16.DecodeRune(r, low); r == utf8.RuneError {
+		return 0, next, fmt.Errorf("invalid surrogate at %d", pos-2)
+	}
+
+	return r, next + 6, nil
+}
+
+// parseJSONPathHex - разбор четырех шестнадцатеричных цифр экранирования "\uXXXX".
+func parseJSONPathHex(str string, pos int) (r rune, err error) {
+	if pos+4 > len(str) {
+		return 0, fmt.Errorf("invalid unicode escape at %d", pos-2)
+	}
+
+	var code uint64
+
+	if code, err = strconv.ParseUint(str[pos:pos+4], 16, 16); err != nil {
+		return 0, fmt.Errorf("invalid unicode escape at %d", pos-2)
+	}
+
+	return rune(code), nil
+}
+
+// isJSONPathIdentifier - проверка, записывается ли имя в JSONPath через точку.
+// Допускаются имена по грамматике member-name-shorthand RFC 9535:
+// первый символ - латинская буква, "_" или символ вне ASCII, далее также цифры.
+func isJSONPathIdentifier(name string) (ok bool) {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_':
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		case r > 0x7f && r != utf8.RuneError:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,454 @@
+package details
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+)
+
+func TestFieldKey_JSONPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		fk      types.DetailsFieldKey
+		wantStr string
+	}{
+		{
+			name:    "Case 1",
+			fk:      new(FieldKey).Add("user").AddArray("items", 3).Add("name"),
+			wantStr: "/user/items/3/name",
+		},
+		{
+			name:    "Case 2",
+			fk:      new(FieldKey).AddMap("labels", "a/b~c"),
+			wantStr: "/labels/a~1b~0c",
+		},
+		{
+			name:    "Case 3",
+			fk:      new(FieldKey),
+			wantStr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.fk.JSONPointer(); gotStr != tt.wantStr {
+				t.Errorf("JSONPointer() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestFieldKey_JSONPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		fk      types.DetailsFieldKey
+		wantStr string
+	}{
+		{
+			name:    "Case 1",
+			fk:      new(FieldKey).Add("user").AddArray("items", 3).Add("name"),
+			wantStr: "$.user.items[3].name",
+		},
+		{
+			name:    "Case 2",
+			fk:      new(FieldKey).Add("a.b").AddMap("labels", "it's").Add(`say "hi"`),
+			wantStr: `$['a.b'].labels['it\'s']['say "hi"']`,
+		},
+		{
+			name:    "Case 3",
+			fk:      new(FieldKey),
+			wantStr: "$",
+		},
+		{
+			name:    "Case 4",
+			fk:      new(FieldKey).Add("user-id", "_id", "имя", "2fa").AddMap("labels", "env"),
+			wantStr: "$['user-id']._id.имя['2fa'].labels.env",
+		},
+		{
+			name:    "Case 5",
+			fk:      new(FieldKey).Add("a\\b\n\x01"),
+			wantStr: `$['a\\b\n\u0001']`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.fk.JSONPath(); gotStr != tt.wantStr {
+				t.Errorf("JSONPath() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestParseJSONPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    *FieldKey
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			str:  "/user/items/3/name",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "user"},
+					{Kind: FieldKeyName, Name: "items"},
+					{Kind: FieldKeyIndex, Index: 3},
+					{Kind: FieldKeyName, Name: "name"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			str:  "/a~1b/~0c/03",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "a/b"},
+					{Kind: FieldKeyName, Name: "~c"},
+					{Kind: FieldKeyName, Name: "03"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			str:  "",
+			want: &FieldKey{
+				path: []FieldKeySegment{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Case 4",
+			str:     "user/name",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 5",
+			str:     "/user~2",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSONPointer(tt.str)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJSONPointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidFieldKey) {
+				t.Errorf("ParseJSONPointer() error = %v, want %v", err, ErrInvalidFieldKey)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJSONPointer() = %#v, want %#v", got, tt.want)
+			}
+
+			if got != nil && got.JSONPointer() != tt.str {
+				t.Errorf("JSONPointer() = %v, want %v", got.JSONPointer(), tt.str)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    *FieldKey
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			str:  "$.user.items[3].name",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "user"},
+					{Kind: FieldKeyName, Name: "items"},
+					{Kind: FieldKeyIndex, Index: 3},
+					{Kind: FieldKeyName, Name: "name"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			str:  `$["a.b"].labels['it\'s'][0]`,
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "a.b"},
+					{Kind: FieldKeyName, Name: "labels"},
+					{Kind: FieldKeyName, Name: "it's"},
+					{Kind: FieldKeyIndex, Index: 0},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			str:  "$",
+			want: &FieldKey{
+				path: []FieldKeySegment{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Case 4",
+			str:     "user.name",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 5",
+			str:     "$.items[x]",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 6",
+			str:     "$['name'",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 7",
+			str:     "$..name",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 8",
+			str:     "$.user-id",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Case 9",
+			str:  `$["say \"hi\""]['a\/b\t\u00e9\ud83d\ude00']`,
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: `say "hi"`},
+					{Kind: FieldKeyName, Name: "a/b\té\U0001F600"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Case 10",
+			str:     `$['a\"b']`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 11",
+			str:     `$['\ud83d']`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSONPath(tt.str)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJSONPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidFieldKey) {
+				t.Errorf("ParseJSONPath() error = %v, want %v", err, ErrInvalidFieldKey)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJSONPath() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldKeyNotation_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		fk       types.DetailsFieldKey
+		notation FieldKeyNotation
+	}{
+		{
+			name:     "Case 1",
+			fk:       new(FieldKey).Add("a.b").AddMap("labels", "a.b").AddArray("items", 2),
+			notation: FieldKeyNotationJSONPath,
+		},
+		{
+			name:     "Case 2",
+			fk:       new(FieldKey).Add(`it's "a.b"`).AddMap("labels", `it's "x"`),
+			notation: FieldKeyNotationJSONPath,
+		},
+		{
+			name:     "Case 3",
+			fk:       new(FieldKey).Add("a.b").Add("c/d~e"),
+			notation: FieldKeyNotationJSONPointer,
+		},
+		{
+			name:     "Case 4",
+			fk:       new(FieldKey).Add("user-id", "a\\b\n\x01", "😀"),
+			notation: FieldKeyNotationJSONPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var str = FormatFieldKey(tt.fk, tt.notation)
+
+			got, err := ParseFieldKeyNotation(str, tt.notation)
+
+			if err != nil {
+				t.Fatalf("ParseFieldKeyNotation(%q) error = %v", str, err)
+			}
+
+			if !got.Equal(tt.fk) {
+				t.Errorf("ParseFieldKeyNotation(%q) = %#v, want %#v", str, got, tt.fk)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath_Quotes(t *testing.T) {
+	var want = new(FieldKey).Add("user").AddMap("labels", "env")
+
+	for i, str := range []string{"$.user.labels.env", "$['user']['labels']['env']", `$["user"]["labels"]["env"]`} {
+		t.Run(fmt.Sprintf("Case %d", i+1), func(t *testing.T) {
+			got, err := ParseJSONPath(str)
+
+			if err != nil {
+				t.Fatalf("ParseJSONPath() error = %v", err)
+			}
+
+			if !got.Equal(want) {
+				t.Errorf("ParseJSONPath() = %v, want %v", got, want)
+			}
+
+			if got.JSONPath() != want.JSONPath() {
+				t.Errorf("JSONPath() = %v, want %v", got.JSONPath(), want.JSONPath())
+			}
+		})
+	}
+}
+
+func TestParseFieldKeyNotation(t *testing.T) {
+	var want = new(FieldKey).Add("user").AddArray("items", 3).Add("name")
+
+	tests := []struct {
+		name     string
+		str      string
+		notation FieldKeyNotation
+	}{
+		{
+			name:     "Case 1",
+			str:      "user.items[3].name",
+			notation: FieldKeyNotationDefault,
+		},
+		{
+			name:     "Case 2",
+			str:      "/user/items/3/name",
+			notation: FieldKeyNotationDefault,
+		},
+		{
+			name:     "Case 3",
+			str:      "$.user.items[3].name",
+			notation: FieldKeyNotationDefault,
+		},
+		{
+			name:     "Case 4",
+			str:      "/user/items/3/name",
+			notation: FieldKeyNotationJSONPointer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFieldKeyNotation(tt.str, tt.notation)
+
+			if err != nil {
+				t.Fatalf("ParseFieldKeyNotation() error = %v", err)
+			}
+
+			if !got.Equal(want) {
+				t.Errorf("ParseFieldKeyNotation() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDetails_Notation(t *testing.T) {
+	t.Cleanup(func() {
+		SetDefaultFieldKeyNotation(FieldKeyNotationDot)
+	})
+
+	var newDetails = func() *Details {
+		var ds = new(Details)
+		ds.SetField(new(FieldKey).Add("user").AddArray("items", 3), new(messages.TextMessage).Text("Message. "))
+
+		return ds
+	}
+
+	tests := []struct {
+		name     string
+		global   FieldKeyNotation
+		notation FieldKeyNotation
+		wantData string
+	}{
+		{
+			name:     "Case 1",
+			global:   FieldKeyNotationDefault,
+			notation: FieldKeyNotationDefault,
+			wantData: `{"fields":{"user.items[3]":"Message. "}}`,
+		},
+		{
+			name:     "Case 2",
+			global:   FieldKeyNotationDefault,
+			notation: FieldKeyNotationJSONPointer,
+			wantData: `{"fields":{"/user/items/3":"Message. "}}`,
+		},
+		{
+			name:     "Case 3",
+			global:   FieldKeyNotationJSONPath,
+			notation: FieldKeyNotationDefault,
+			wantData: `{"fields":{"$.user.items[3]":"Message. "}}`,
+		},
+		{
+			name:     "Case 4",
+			global:   FieldKeyNotationJSONPath,
+			notation: FieldKeyNotationDot,
+			wantData: `{"fields":{"user.items[3]":"Message. "}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaultFieldKeyNotation(tt.global)
+
+			var ds = newDetails().Notation(tt.notation)
+
+			for _, d := range []types.Details{ds, ds.Clone()} {
+				gotData, err := json.Marshal(d)
+
+				if err != nil {
+					t.Fatalf("MarshalJSON() error = %v", err)
+				}
+
+				if string(gotData) != tt.wantData {
+					t.Errorf("MarshalJSON() = %s, want %s", gotData, tt.wantData)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package details
 
 import (
+	"errors"
 	"reflect"
 	"sm-errors/types"
 	"testing"
@@ -8,7 +9,7 @@ import (
 
 func TestFieldKey_Add(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	type args struct {
@@ -24,31 +25,31 @@ func TestFieldKey_Add(t *testing.T) {
 		{
 			name: "Case 1",
 			fields: fields{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 			args: args{
 				name: "test",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 		},
 		{
 			name: "Case 2",
 			fields: fields{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			args: args{
 				name: "123",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
-					"123",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyName, Name: "123"},
 				},
 			},
 		},
@@ -59,8 +60,8 @@ func TestFieldKey_Add(t *testing.T) {
 				name: "test",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 		},
@@ -81,7 +82,7 @@ func TestFieldKey_Add(t *testing.T) {
 
 func TestFieldKey_AddArray(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	type args struct {
@@ -98,23 +99,24 @@ func TestFieldKey_AddArray(t *testing.T) {
 		{
 			name: "Case 1",
 			fields: fields{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 			args: args{
 				name:  "test",
 				index: 0,
 			},
 			want: &FieldKey{
-				path: []string{
-					"test[0]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyIndex, Index: 0},
 				},
 			},
 		},
 		{
 			name: "Case 2",
 			fields: fields{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			args: args{
@@ -122,9 +124,10 @@ func TestFieldKey_AddArray(t *testing.T) {
 				index: 0,
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
-					"arr[0]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyName, Name: "arr"},
+					{Kind: FieldKeyIndex, Index: 0},
 				},
 			},
 		},
@@ -136,8 +139,9 @@ func TestFieldKey_AddArray(t *testing.T) {
 				index: 0,
 			},
 			want: &FieldKey{
-				path: []string{
-					"arr[0]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "arr"},
+					{Kind: FieldKeyIndex, Index: 0},
 				},
 			},
 		},
@@ -158,7 +162,7 @@ func TestFieldKey_AddArray(t *testing.T) {
 
 func TestFieldKey_AddMap(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	type args struct {
@@ -175,23 +179,24 @@ func TestFieldKey_AddMap(t *testing.T) {
 		{
 			name: "Case 1",
 			fields: fields{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 			args: args{
 				name: "test",
 				key:  "key",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test[key]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyMap, Name: "key"},
 				},
 			},
 		},
 		{
 			name: "Case 2",
 			fields: fields{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			args: args{
@@ -199,9 +204,10 @@ func TestFieldKey_AddMap(t *testing.T) {
 				key:  "key",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
-					"map[key]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyName, Name: "map"},
+					{Kind: FieldKeyMap, Name: "key"},
 				},
 			},
 		},
@@ -213,8 +219,9 @@ func TestFieldKey_AddMap(t *testing.T) {
 				key:  "key",
 			},
 			want: &FieldKey{
-				path: []string{
-					"test[key]",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyMap, Name: "key"},
 				},
 			},
 		},
@@ -235,7 +242,7 @@ func TestFieldKey_AddMap(t *testing.T) {
 
 func TestFieldKey_Clone(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	tests := []struct {
@@ -247,28 +254,28 @@ func TestFieldKey_Clone(t *testing.T) {
 			name:   "Case 1",
 			fields: fields{},
 			want: &FieldKey{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 		},
 		{
 			name: "Case 2",
 			fields: fields{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 			want: &FieldKey{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 		},
 		{
 			name: "Case 3",
 			fields: fields{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 		},
@@ -289,7 +296,7 @@ func TestFieldKey_Clone(t *testing.T) {
 
 func TestFieldKey_String(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	tests := []struct {
@@ -300,8 +307,8 @@ func TestFieldKey_String(t *testing.T) {
 		{
 			name: "Case 1",
 			fields: fields{
-				[]string{
-					"test",
+				[]FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			wantStr: "test",
@@ -309,9 +316,10 @@ func TestFieldKey_String(t *testing.T) {
 		{
 			name: "Case 2",
 			fields: fields{
-				[]string{
-					"test",
-					"arr[0]",
+				[]FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyName, Name: "arr"},
+					{Kind: FieldKeyIndex, Index: 0},
 				},
 			},
 			wantStr: "test.arr[0]",
@@ -319,9 +327,10 @@ func TestFieldKey_String(t *testing.T) {
 		{
 			name: "Case 3",
 			fields: fields{
-				[]string{
-					"test",
-					"map[key]",
+				[]FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
+					{Kind: FieldKeyName, Name: "map"},
+					{Kind: FieldKeyMap, Name: "key"},
 				},
 			},
 			wantStr: "test.map[key]",
@@ -329,7 +338,7 @@ func TestFieldKey_String(t *testing.T) {
 		{
 			name: "Case 4",
 			fields: fields{
-				[]string{},
+				[]FieldKeySegment{},
 			},
 			wantStr: "",
 		},
@@ -350,7 +359,7 @@ func TestFieldKey_String(t *testing.T) {
 
 func TestFieldKey_init(t *testing.T) {
 	type fields struct {
-		path []string
+		path []FieldKeySegment
 	}
 
 	tests := []struct {
@@ -361,29 +370,29 @@ func TestFieldKey_init(t *testing.T) {
 		{
 			name: "Case 1",
 			fields: fields{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 			want: &FieldKey{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 		},
 		{
 			name:   "Case 2",
 			fields: fields{},
 			want: &FieldKey{
-				path: []string{},
+				path: []FieldKeySegment{},
 			},
 		},
 		{
 			name: "Case 3",
 			fields: fields{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 			want: &FieldKey{
-				path: []string{
-					"test",
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "test"},
 				},
 			},
 		},
@@ -403,3 +412,225 @@ func TestFieldKey_init(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFieldKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    *FieldKey
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			str:  "user.items[3].name",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "user"},
+					{Kind: FieldKeyName, Name: "items"},
+					{Kind: FieldKeyIndex, Index: 3},
+					{Kind: FieldKeyName, Name: "name"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			str:  "labels[env].matrix[0][1]",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "labels"},
+					{Kind: FieldKeyMap, Name: "env"},
+					{Kind: FieldKeyName, Name: "matrix"},
+					{Kind: FieldKeyIndex, Index: 0},
+					{Kind: FieldKeyIndex, Index: 1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			str:  `file\.name.labels[a.b\]c][\7]`,
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyName, Name: "file.name"},
+					{Kind: FieldKeyName, Name: "labels"},
+					{Kind: FieldKeyMap, Name: "a.b]c"},
+					{Kind: FieldKeyMap, Name: "7"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 4",
+			str:  "",
+			want: &FieldKey{
+				path: []FieldKeySegment{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 5",
+			str:  "[2].id",
+			want: &FieldKey{
+				path: []FieldKeySegment{
+					{Kind: FieldKeyIndex, Index: 2},
+					{Kind: FieldKeyName, Name: "id"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Case 6",
+			str:     "items[3",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 7",
+			str:     "items]",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 8",
+			str:     "items[0]name",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Case 9",
+			str:     `name\`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFieldKey(tt.str)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFieldKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if !errors.Is(err, ErrInvalidFieldKey) {
+					t.Errorf("ParseFieldKey() error = %v, want %v", err, ErrInvalidFieldKey)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFieldKey() = %#v, want %#v", got, tt.want)
+			}
+
+			if str := got.String(); str != tt.str {
+				t.Errorf("String() = %v, want %v", str, tt.str)
+			}
+		})
+	}
+}
+
+func TestFieldKey_String_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		fk   types.DetailsFieldKey
+		want string
+	}{
+		{
+			name: "Case 1",
+			fk:   new(FieldKey).Add("user").AddArray("items", 3).Add("name"),
+			want: "user.items[3].name",
+		},
+		{
+			name: "Case 2",
+			fk:   new(FieldKey).Add("a.b", "c[d]", `e\f`),
+			want: `a\.b.c\[d\].e\\f`,
+		},
+		{
+			name: "Case 3",
+			fk:   new(FieldKey).AddMap("labels", "x.y[z]").AddMap("", 10),
+			want: `labels[x.y\[z\]][\10]`,
+		},
+		{
+			name: "Case 4",
+			fk:   new(FieldKey).AddArray("matrix", 0).AddArray("", 1),
+			want: "matrix[0][1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var str = tt.fk.String()
+
+			if str != tt.want {
+				t.Errorf("String() = %v, want %v", str, tt.want)
+			}
+
+			got, err := ParseFieldKey(str)
+
+			if err != nil {
+				t.Fatalf("ParseFieldKey() error = %v", err)
+			}
+
+			if !got.Equal(tt.fk) {
+				t.Errorf("ParseFieldKey() = %#v, want %#v", got, tt.fk)
+			}
+		})
+	}
+}
+
+func TestFieldKey_Equal(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *FieldKey
+		b    types.DetailsFieldKey
+		want bool
+	}{
+		{
+			name: "Case 1",
+			a:    new(FieldKey).Add("user").AddArray("items", 1).(*FieldKey),
+			b:    new(FieldKey).Add("user").AddArray("items", 1),
+			want: true,
+		},
+		{
+			name: "Case 2",
+			a:    new(FieldKey).AddArray("items", 1).(*FieldKey),
+			b:    new(FieldKey).AddMap("items", 1),
+			want: false,
+		},
+		{
+			name: "Case 3",
+			a:    new(FieldKey).Add("user.name").(*FieldKey),
+			b:    new(FieldKey).Add("user", "name"),
+			want: false,
+		},
+		{
+			name: "Case 4",
+			a:    new(FieldKey).Add("user").(*FieldKey),
+			b:    nil,
+			want: false,
+		},
+		{
+			name: "Case 5",
+			a:    new(FieldKey).Add("labels", "env").(*FieldKey),
+			b:    new(FieldKey).AddMap("labels", "env"),
+			want: true,
+		},
+		{
+			name: "Case 6",
+			a:    new(FieldKey).AddMap("items", "1").(*FieldKey),
+			b:    new(FieldKey).Add("items", "1"),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
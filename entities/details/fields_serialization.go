@@ -4,43 +4,263 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"sort"
 )
 
+// notatedFields - поля ошибки с записью ключей для упаковки.
+type notatedFields struct {
+	list     Fields
+	notation FieldKeyNotation
+}
+
 // MarshalJSON - упаковать в формат JSON.
+// Ключи полей записываются в записи по умолчанию, см. SetDefaultFieldKeyNotation.
 func (list Fields) MarshalJSON() ([]byte, error) {
-	var w = make(map[string]any)
+	return notatedFields{list: list}.MarshalJSON()
+}
 
-	for _, f := range list {
-		if _, ok := f.Message.(json.Marshaler); !ok {
-			if v, ok := f.Message.(fmt.Stringer); ok {
-				w[f.Key.String()] = v.String()
-			} else {
-				w[f.Key.String()] = nil
-			}
-		} else {
-			w[f.Key.String()] = f
+// MarshalXML - упаковать в формат XML.
+// Ключи полей записываются в записи по умолчанию, см. SetDefaultFieldKeyNotation.
+func (list Fields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err error) {
+	return notatedFields{list: list}.MarshalXML(encoder, start)
+}
+
+// MarshalJSON - упаковать в формат JSON.
+// Поля упаковываются в порядке добавления.
+func (nf notatedFields) MarshalJSON() ([]byte, error) {
+	var members = make([]jsonMember, 0, len(nf.list))
+
+	for _, f := range nf.list {
+		var data, err = messages.EncodeMessage(f.Message)
+
+		if err != nil {
+			return nil, err
 		}
+
+		members = append(members, jsonMember{key: FormatFieldKey(f.Key, nf.notation), value: json.RawMessage(data)})
 	}
 
-	return json.Marshal(w)
+	return marshalJSONObject(members)
 }
 
 // MarshalXML - упаковать в формат XML.
-func (list Fields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err error) {
-	var w = make(map[string]any)
+// Поля упаковываются в порядке добавления.
+func (nf notatedFields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err error) {
+	start = xml.StartElement{
+		Name: xml.Name{
+			Local: "Item",
+		},
+		Attr: []xml.Attr{
+			{
+				Name: xml.Name{
+					Local: "key",
+				},
+				Value: KeyFields,
+			},
+		},
+	}
+
+	if err = encoder.EncodeToken(start); err != nil {
+		return
+	}
+
+	for _, f := range nf.list {
+		var v any
+
+		if _, ok := f.Message.(xml.Marshaler); ok {
+			v = f.Message
+		} else if m, ok := f.Message.(fmt.Stringer); ok {
+			v = m.String()
+		}
+
+		var subElement = xml.StartElement{
+			Name: xml.Name{
+				Local: "Field",
+			},
+			Attr: []xml.Attr{
+				{
+					Name: xml.Name{
+						Local: "key",
+					},
+					Value: FormatFieldKey(f.Key, nf.notation),
+				},
+			},
+		}
+
+		if err = encoder.EncodeElement(v, subElement); err != nil {
+			return
+		}
+	}
+
+	if err = encoder.EncodeToken(start.End()); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalXML - распаковать из формата XML.
+// Ключи полей разбираются в любой записи, см. ParseFieldKeyNotation.
+func (list *Fields) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) (err error) {
+	*list = make(Fields, 0)
+
+	return decodeXMLChildren(decoder, func(el xml.StartElement) (err error) {
+		if el.Name.Local != "Field" {
+			return decoder.Skip()
+		}
+
+		var m types.Message
+
+		if m, err = messages.DecodeMessageXML(decoder, el); err != nil {
+			return
+		}
+
+		*list = append(*list, &types.DetailsField{
+			Key:     decodeFieldKey(xmlAttr(el, "key")),
+			Message: m,
+		})
+
+		return
+	})
+}
+
+type (
+	// notatedViolations - нарушения правил полей ошибки с записью ключей для упаковки.
+	notatedViolations notatedFields
 
+	// violationWrapper - структура обертка для упаковки нарушения правила поля.
+	violationWrapper struct {
+		Code    string          `json:"code"`
+		Params  map[string]any  `json:"params,omitempty"`
+		Message json.RawMessage `json:"message"`
+	}
+
+	// violationXMLWrapper - структура обертка для упаковки нарушения правила поля в формат XML.
+	violationXMLWrapper struct {
+		Code    string                  `xml:"code,attr"`
+		Params  []violationParamWrapper `xml:"Param,omitempty"`
+		Message any                     `xml:"Message"`
+	}
+
+	// violationParamWrapper - структура обертка для упаковки параметра нарушения в формат XML.
+	violationParamWrapper struct {
+		Name  string `xml:"name,attr"`
+		Value any    `xml:",chardata"`
+	}
+
+	// violationXMLReader - структура обертка для распаковки нарушения правила поля из формата XML.
+	violationXMLReader struct {
+		Code   string `xml:"code,attr"`
+		Params []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"Param"`
+		Message *xmlMessage `xml:"Message"`
+	}
+
+	// xmlMessage - сообщение для распаковки из формата XML.
+	xmlMessage struct {
+		message types.Message
+	}
+)
+
+// UnmarshalXML - распаковать из формата XML.
+func (m *xmlMessage) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) (err error) {
+	m.message, err = messages.DecodeMessageXML(decoder, start)
+
+	return
+}
+
+// decodeViolationsXML - распаковать нарушения правил полей из формата XML.
+// Параметры нарушений передаются в XML текстом и распаковываются строками.
+func decodeViolationsXML(decoder *xml.Decoder, fn func(k types.DetailsFieldKey, v types.DetailsFieldViolation)) (err error) {
+	return decodeXMLChildren(decoder, func(el xml.StartElement) (err error) {
+		if el.Name.Local != "Field" {
+			return decoder.Skip()
+		}
+
+		var w struct {
+			Violations []violationXMLReader `xml:"Violation"`
+		}
+
+		if err = decoder.DecodeElement(&w, &el); err != nil {
+			return
+		}
+
+		var key = decodeFieldKey(xmlAttr(el, "key"))
+
+		for _, r := range w.Violations {
+			var v = types.DetailsFieldViolation{
+				Code: r.Code,
+			}
+
+			if r.Message != nil {
+				v.Message = r.Message.message
+			}
+
+			if len(r.Params) > 0 {
+				v.Params = make(map[string]any, len(r.Params))
+
+				for _, p := range r.Params {
+					v.Params[p.Name] = p.Value
+				}
+			}
+
+			fn(key, v)
+		}
+
+		return
+	})
+}
+
+// hasViolations - проверка, есть ли у полей нарушения правил.
+func (list Fields) hasViolations() (ok bool) {
 	for _, f := range list {
-		if _, ok := f.Message.(xml.Marshaler); !ok {
-			if v, ok := f.Message.(fmt.Stringer); ok {
-				w[f.Key.String()] = v.String()
-			} else {
-				w[f.Key.String()] = nil
+		if len(f.Violations) > 0 {
+			return true
+		}
+	}
+
+	return
+}
+
+// MarshalJSON - упаковать в формат JSON.
+// Нарушения упаковываются списками по ключам полей в порядке добавления полей:
+// {"password": [{"code": "min_length", ...}]}.
+func (nv notatedViolations) MarshalJSON() ([]byte, error) {
+	var members = make([]jsonMember, 0, len(nv.list))
+
+	for _, f := range nv.list {
+		if len(f.Violations) == 0 {
+			continue
+		}
+
+		var list = make([]violationWrapper, 0, len(f.Violations))
+
+		for _, v := range f.Violations {
+			var data, err = messages.EncodeMessage(v.Message)
+
+			if err != nil {
+				return nil, err
 			}
-		} else {
-			w[f.Key.String()] = f
+
+			list = append(list, violationWrapper{
+				Code:    v.Code,
+				Params:  v.Params,
+				Message: data,
+			})
 		}
+
+		members = append(members, jsonMember{key: FormatFieldKey(f.Key, nv.notation), value: list})
 	}
 
+	return marshalJSONObject(members)
+}
+
+// MarshalXML - упаковать в формат XML.
+func (nv notatedViolations) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err error) {
 	start = xml.StartElement{
 		Name: xml.Name{
 			Local: "Item",
@@ -50,7 +270,7 @@ func (list Fields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err
 				Name: xml.Name{
 					Local: "key",
 				},
-				Value: "fields",
+				Value: KeyViolations,
 			},
 		},
 	}
@@ -59,7 +279,39 @@ func (list Fields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err
 		return
 	}
 
-	for k, v := range w {
+	for _, f := range nv.list {
+		if len(f.Violations) == 0 {
+			continue
+		}
+
+		var list = make([]violationXMLWrapper, 0, len(f.Violations))
+
+		for _, v := range f.Violations {
+			var w = violationXMLWrapper{
+				Code: v.Code,
+			}
+
+			if _, ok := v.Message.(xml.Marshaler); ok {
+				w.Message = v.Message
+			} else if v.Message != nil {
+				w.Message = v.Message.String()
+			}
+
+			var names = make([]string, 0, len(v.Params))
+
+			for name := range v.Params {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				w.Params = append(w.Params, violationParamWrapper{Name: name, Value: v.Params[name]})
+			}
+
+			list = append(list, w)
+		}
+
 		var subElement = xml.StartElement{
 			Name: xml.Name{
 				Local: "Field",
@@ -69,12 +321,18 @@ func (list Fields) MarshalXML(encoder *xml.Encoder, start xml.StartElement) (err
 					Name: xml.Name{
 						Local: "key",
 					},
-					Value: k,
+					Value: FormatFieldKey(f.Key, nv.notation),
 				},
 			},
 		}
 
-		if err = encoder.EncodeElement(v, subElement); err != nil {
+		var fw = struct {
+			Violations []violationXMLWrapper `xml:"Violation"`
+		}{
+			Violations: list,
+		}
+
+		if err = encoder.EncodeElement(fw, subElement); err != nil {
 			return
 		}
 	}
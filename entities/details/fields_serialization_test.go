@@ -145,3 +145,43 @@ func TestFields_MarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestFields_UnmarshalXML(t *testing.T) {
+	var list = Fields{
+		{
+			Key:     new(FieldKey).Add("test"),
+			Message: new(messages.TextMessage).Text("123"),
+		},
+		{
+			Key:     new(FieldKey).Add("test").AddArray("arr", 1),
+			Message: new(messages.TextMessage).Text("hzz"),
+		},
+	}
+
+	var data, err = xml.Marshal(list)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Fields
+
+	if err = xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, list) {
+		t.Errorf("UnmarshalXML() got = %v, want %v", got, list)
+	}
+
+	// Ключи в записи JSON Pointer разбираются автоматически.
+	data = []byte(`<Item key="fields"><Field key="/user/items/3">Invalid</Field><Unknown/></Item>`)
+
+	if err = xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || got[0].Key.String() != "user.items[3]" || got[0].Message.String() != "Invalid" {
+		t.Errorf("UnmarshalXML() got = %v", got)
+	}
+}
@@ -0,0 +1,239 @@
+package details
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sm-errors/types"
+	"strconv"
+)
+
+// Key - типизированный ключ деталей ошибки.
+// Установка и получение значения по такому ключу согласуют тип значения при компиляции:
+//
+//	var AttemptKey = details.Key[int]("attempt")
+//
+//	AttemptKey.Set(e.Details(), 3)
+//	attempt, ok := AttemptKey.Get(e.Details())
+type Key[T any] string
+
+// Name - получение имени ключа.
+func (k Key[T]) Name() (name string) {
+	return string(k)
+}
+
+// Set - установить значение в детали по ключу.
+func (k Key[T]) Set(d types.Details, v T) types.Details {
+	return d.Set(string(k), v)
+}
+
+// Get - получение значения из деталей по ключу, см. Get.
+func (k Key[T]) Get(d types.Details) (v T, ok bool) {
+	return Get[T](d, string(k))
+}
+
+// MustGet - получение значения из деталей по ключу с паникой в случае ошибки, см. MustGet.
+func (k Key[T]) MustGet(d types.Details) (v T) {
+	return MustGet[T](d, string(k))
+}
+
+// Get - получение значения из деталей по ключу с приведением к типу T.
+// Числа приводятся без потери точности: 3.0 приводится к int, 3.5 и значения вне
+// диапазона типа - нет. Объекты, ставшие map[string]any после
+// распаковки деталей, распаковываются в структуры через формат JSON.
+// Если значения нет или его нельзя привести к типу T, возвращается false.
+func Get[T any](d types.Details, k string) (v T, ok bool) {
+	if d == nil {
+		return
+	}
+
+	return convert[T](d.Peek(k))
+}
+
+// MustGet - получение значения из деталей по ключу с приведением к типу T и паникой,
+// если значения нет или его нельзя привести к типу T.
+func MustGet[T any](d types.Details, k string) (v T) {
+	var ok bool
+
+	if v, ok = Get[T](d, k); !ok {
+		panic(fmt.Sprintf("details: value of key '%s' is missing or not convertible to %s", k, reflect.TypeFor[T]()))
+	}
+
+	return
+}
+
+// convert - приведение значения к типу T.
+func convert[T any](src any) (v T, ok bool) {
+	if src == nil {
+		return
+	}
+
+	if v, ok = src.(T); ok {
+		return
+	}
+
+	var target = reflect.ValueOf(&v).Elem()
+
+	if isNumberKind(target.Kind()) {
+		if ok = convertNumber(reflect.ValueOf(src), target); !ok {
+			v = *new(T)
+		}
+
+		return
+	}
+
+	var data, err = json.Marshal(src)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return *new(T), false
+	}
+
+	return v, true
+}
+
+// isNumberKind - проверка, является ли вид числовым.
+func isNumberKind(k reflect.Kind) (ok bool) {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return
+}
+
+// convertNumber - приведение числа к числовому типу без потери точности.
+func convertNumber(src, dst reflect.Value) (ok bool) {
+	if n, isNumber := src.Interface().(json.Number); isNumber {
+		if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+			src = reflect.ValueOf(i)
+		} else if f, err := strconv.ParseFloat(string(n), 64); err == nil {
+			src = reflect.ValueOf(f)
+		} else {
+			return
+		}
+	}
+
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setInt(src.Int(), dst)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return setUint(src.Uint(), dst)
+	case reflect.Float32, reflect.Float64:
+		return setFloat(src.Float(), dst)
+	}
+
+	return
+}
+
+// setInt - установить целое число со знаком.
+func setInt(i int64, dst reflect.Value) (ok bool) {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		{
+			if dst.OverflowInt(i) {
+				return
+			}
+
+			dst.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		{
+			if i < 0 || dst.OverflowUint(uint64(i)) {
+				return
+			}
+
+			dst.SetUint(uint64(i))
+		}
+	case reflect.Float32, reflect.Float64:
+		{
+			var f = float64(i)
+
+			if f >= math.MaxInt64 || int64(f) != i || dst.OverflowFloat(f) {
+				return
+			}
+
+			if dst.Kind() == reflect.Float32 && int64(float32(f)) != i {
+				return
+			}
+
+			dst.SetFloat(f)
+		}
+	}
+
+	return true
+}
+
+// setUint - установить целое число без знака.
+func setUint(u uint64, dst reflect.Value) (ok bool) {
+	if u <= math.MaxInt64 {
+		return setInt(int64(u), dst)
+	}
+
+	switch dst.Kind() {
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		{
+			if dst.OverflowUint(u) {
+				return
+			}
+
+			dst.SetUint(u)
+
+			return true
+		}
+	}
+
+	return
+}
+
+// setFloat - установить дробное число.
+func setFloat(f float64, dst reflect.Value) (ok bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		if dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64 {
+			dst.SetFloat(f)
+			return true
+		}
+
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Float32:
+		{
+			if float64(float32(f)) != f {
+				return
+			}
+
+			dst.SetFloat(f)
+
+			return true
+		}
+	case reflect.Float64:
+		{
+			dst.SetFloat(f)
+
+			return true
+		}
+	}
+
+	// Целые типы: только целые значения в диапазоне int64 или uint64.
+	if f != math.Trunc(f) {
+		return
+	}
+
+	if f >= -math.MaxInt64-1 && f < math.MaxInt64 {
+		return setInt(int64(f), dst)
+	}
+
+	if f >= 0 && f < math.MaxUint64 {
+		return setUint(uint64(f), dst)
+	}
+
+	return
+}
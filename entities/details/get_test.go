@@ -0,0 +1,179 @@
+package details
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// testUser - структура для тестов получения значений.
+type testUser struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+func TestGet(t *testing.T) {
+	var ds = new(Details).
+		Set("int", 3).
+		Set("float_int", float64(42)).
+		Set("float", 3.5).
+		Set("big", float64(1<<60)).
+		Set("negative", float64(-1)).
+		Set("number", json.Number("17")).
+		Set("string", "value").
+		Set("user", map[string]any{"id": float64(1), "name": "test", "roles": []any{"admin"}}).
+		Set("struct", testUser{ID: 2, Name: "struct"})
+
+	// int
+	{
+		tests := []struct {
+			name   string
+			key    string
+			wantV  int
+			wantOk bool
+		}{
+			{name: "Case 1", key: "int", wantV: 3, wantOk: true},
+			{name: "Case 2", key: "float_int", wantV: 42, wantOk: true},
+			{name: "Case 3", key: "float", wantV: 0, wantOk: false},
+			{name: "Case 4", key: "number", wantV: 17, wantOk: true},
+			{name: "Case 5", key: "string", wantV: 0, wantOk: false},
+			{name: "Case 6", key: "missing", wantV: 0, wantOk: false},
+		}
+
+		for _, tt := range tests {
+			t.Run("int "+tt.name, func(t *testing.T) {
+				gotV, gotOk := Get[int](ds, tt.key)
+
+				if gotV != tt.wantV || gotOk != tt.wantOk {
+					t.Errorf("Get() = %v, %v, want %v, %v", gotV, gotOk, tt.wantV, tt.wantOk)
+				}
+			})
+		}
+	}
+
+	// Числа с ограниченным диапазоном
+	{
+		if v, ok := Get[int8](ds, "big"); ok {
+			t.Errorf("Get[int8]() = %v, want overflow", v)
+		}
+
+		if v, ok := Get[uint](ds, "negative"); ok {
+			t.Errorf("Get[uint]() = %v, want negative rejected", v)
+		}
+
+		if v, ok := Get[int64](ds, "big"); !ok || v != 1<<60 {
+			t.Errorf("Get[int64]() = %v, %v, want %v", v, ok, int64(1<<60))
+		}
+
+		if v, ok := Get[float32](ds, "float"); !ok || v != 3.5 {
+			t.Errorf("Get[float32]() = %v, %v, want 3.5", v, ok)
+		}
+
+		if v, ok := Get[float64](ds, "int"); !ok || v != 3 {
+			t.Errorf("Get[float64]() = %v, %v, want 3", v, ok)
+		}
+	}
+
+	// Структуры
+	{
+		var want = testUser{ID: 1, Name: "test", Roles: []string{"admin"}}
+
+		if v, ok := Get[testUser](ds, "user"); !ok || !reflect.DeepEqual(v, want) {
+			t.Errorf("Get[testUser]() = %v, %v, want %v", v, ok, want)
+		}
+
+		if v, ok := Get[*testUser](ds, "user"); !ok || !reflect.DeepEqual(*v, want) {
+			t.Errorf("Get[*testUser]() = %v, %v, want %v", v, ok, want)
+		}
+
+		if v, ok := Get[testUser](ds, "struct"); !ok || v.Name != "struct" {
+			t.Errorf("Get[testUser]() = %v, %v", v, ok)
+		}
+
+		if v, ok := Get[testUser](ds, "string"); ok {
+			t.Errorf("Get[testUser]() = %v, want not ok", v)
+		}
+	}
+
+	// После копирования
+	{
+		var c = ds.Clone()
+
+		if v, ok := Get[int](c, "int"); !ok || v != 3 {
+			t.Errorf("Get[int]() after Clone() = %v, %v, want 3", v, ok)
+		}
+
+		if v, ok := Get[testUser](c, "struct"); !ok || v.ID != 2 {
+			t.Errorf("Get[testUser]() after Clone() = %v, %v", v, ok)
+		}
+	}
+
+	if v, ok := Get[int](nil, "int"); ok {
+		t.Errorf("Get() with nil details = %v, want not ok", v)
+	}
+}
+
+func Test_convert_Numbers(t *testing.T) {
+	if _, ok := convert[int64](float64(math.MaxInt64)); ok {
+		t.Errorf("convert[int64](MaxInt64 as float64) ok, want overflow")
+	}
+
+	if v, ok := convert[uint64](uint64(math.MaxUint64)); !ok || v != math.MaxUint64 {
+		t.Errorf("convert[uint64]() = %v, %v", v, ok)
+	}
+
+	if _, ok := convert[int64](uint64(math.MaxUint64)); ok {
+		t.Errorf("convert[int64](MaxUint64) ok, want overflow")
+	}
+
+	if _, ok := convert[float64](int64(1<<53 + 1)); ok {
+		t.Errorf("convert[float64](2^53+1) ok, want precision loss")
+	}
+
+	if _, ok := convert[float32](0.1); ok {
+		t.Errorf("convert[float32](0.1) ok, want precision loss")
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	var ds = new(Details).Set("attempt", float64(2))
+
+	if got := MustGet[int](ds, "attempt"); got != 2 {
+		t.Errorf("MustGet() = %v, want 2", got)
+	}
+
+	defer func() {
+		if r := recover(); r != "details: value of key 'missing' is missing or not convertible to int" {
+			t.Errorf("MustGet() panic = %v", r)
+		}
+	}()
+
+	MustGet[int](ds, "missing")
+}
+
+func TestKey(t *testing.T) {
+	var (
+		attempt = Key[int]("attempt")
+		user    = Key[testUser]("user")
+		ds      = new(Details)
+	)
+
+	attempt.Set(ds, 5)
+	user.Set(ds, testUser{ID: 1, Name: "test"})
+
+	var c = ds.Clone()
+
+	if v, ok := attempt.Get(c); !ok || v != 5 {
+		t.Errorf("Key.Get() = %v, %v, want 5", v, ok)
+	}
+
+	if v := user.MustGet(c); v.Name != "test" {
+		t.Errorf("Key.MustGet() = %v", v)
+	}
+
+	if name := attempt.Name(); name != "attempt" {
+		t.Errorf("Key.Name() = %v, want attempt", name)
+	}
+}
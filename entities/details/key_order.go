@@ -0,0 +1,143 @@
+package details
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// KeyOrder - порядок ключей хранилища при упаковке деталей ошибки.
+// Поля ошибки всегда упаковываются в порядке добавления.
+type KeyOrder uint8
+
+// Порядки ключей хранилища.
+const (
+	// KeyOrderDefault - порядок, установленный по умолчанию, см. SetDefaultKeyOrder.
+	KeyOrderDefault KeyOrder = iota
+	// KeyOrderSorted - ключи отсортированы по возрастанию.
+	KeyOrderSorted
+	// KeyOrderInsertion - ключи в порядке первой установки значения.
+	KeyOrderInsertion
+)
+
+// defaultKeyOrder - порядок ключей хранилища по умолчанию.
+var defaultKeyOrder = struct {
+	order KeyOrder
+	rwMux *sync.RWMutex
+}{
+	order: KeyOrderSorted,
+	rwMux: new(sync.RWMutex),
+}
+
+// SetDefaultKeyOrder - установить порядок ключей хранилища по умолчанию.
+// Значение KeyOrderDefault возвращает сортировку ключей.
+func SetDefaultKeyOrder(o KeyOrder) {
+	if o == KeyOrderDefault {
+		o = KeyOrderSorted
+	}
+
+	defaultKeyOrder.rwMux.Lock()
+	defer defaultKeyOrder.rwMux.Unlock()
+
+	defaultKeyOrder.order = o
+}
+
+// DefaultKeyOrder - получение порядка ключей хранилища по умолчанию.
+func DefaultKeyOrder() (o KeyOrder) {
+	defaultKeyOrder.rwMux.RLock()
+	defer defaultKeyOrder.rwMux.RUnlock()
+
+	return defaultKeyOrder.order
+}
+
+// String - получение названия порядка.
+func (o KeyOrder) String() (str string) {
+	switch o {
+	case KeyOrderDefault:
+		return "default"
+	case KeyOrderSorted:
+		return "sorted"
+	case KeyOrderInsertion:
+		return "insertion"
+	}
+
+	return fmt.Sprintf("KeyOrder(%d)", uint8(o))
+}
+
+// Order - установить порядок ключей хранилища при упаковке деталей ошибки.
+// По умолчанию используется порядок, установленный SetDefaultKeyOrder.
+func (ds *Details) Order(o KeyOrder) *Details {
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.init()
+
+	ds.order = o
+
+	return ds
+}
+
+// resolveOrder - получение порядка ключей хранилища с учетом порядка по умолчанию.
+func (ds *Details) resolveOrder() (o KeyOrder) {
+	if o = ds.order; o == KeyOrderDefault {
+		o = DefaultKeyOrder()
+	}
+
+	return
+}
+
+// storageKeys - получение ключей хранилища в порядке упаковки.
+// Вызывается под блокировкой. Ключи без записанного порядка установки следуют за остальными по возрастанию.
+func (ds *Details) storageKeys() (keys []string) {
+	keys = make([]string, 0, len(ds.storage))
+
+	if ds.resolveOrder() == KeyOrderInsertion {
+		var seen = make(map[string]struct{}, len(ds.keys))
+
+		for _, k := range ds.keys {
+			if _, ok := ds.storage[k]; !ok {
+				continue
+			}
+
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+
+		var rest = make([]string, 0, len(ds.storage)-len(keys))
+
+		for k := range ds.storage {
+			if _, ok := seen[k]; !ok {
+				rest = append(rest, k)
+			}
+		}
+
+		sort.Strings(rest)
+
+		return append(keys, rest...)
+	}
+
+	for k := range ds.storage {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return
+}
+
+// sortedKeys - получение отсортированных ключей карты.
+func sortedKeys(m map[string]any) (keys []string) {
+	keys = make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return
+}
@@ -0,0 +1,157 @@
+package details
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sm-errors/entities/messages"
+	"testing"
+)
+
+// newOrderDetails - детали ошибки с ключами и полями не в порядке сортировки для тестов.
+func newOrderDetails() *Details {
+	var ds = new(Details)
+
+	ds.Set("zeta", 1).Set("alpha", 2).Set("mid", map[string]any{"y": 1, "x": 2})
+	ds.SetField(new(FieldKey).Add("name"), new(messages.TextMessage).Text("Required"))
+	ds.SetField(new(FieldKey).Add("age"), new(messages.TextMessage).Text("Too small"))
+
+	return ds
+}
+
+func TestDetails_Order_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		order KeyOrder
+		want  string
+	}{
+		{
+			name:  "Case 1",
+			order: KeyOrderDefault,
+			want:  `{"alpha":2,"fields":{"name":"Required","age":"Too small"},"mid":{"x":2,"y":1},"zeta":1}`,
+		},
+		{
+			name:  "Case 2",
+			order: KeyOrderSorted,
+			want:  `{"alpha":2,"fields":{"name":"Required","age":"Too small"},"mid":{"x":2,"y":1},"zeta":1}`,
+		},
+		{
+			name:  "Case 3",
+			order: KeyOrderInsertion,
+			want:  `{"zeta":1,"alpha":2,"mid":{"x":2,"y":1},"fields":{"name":"Required","age":"Too small"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds = newOrderDetails().Order(tt.order)
+
+			// Упаковка повторяется, чтобы обнаружить порядок обхода карт.
+			for i := 0; i < 20; i++ {
+				var data, err = json.Marshal(ds)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(data) != tt.want {
+					t.Fatalf("MarshalJSON() got = %s, want %s", data, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetails_Order_XML(t *testing.T) {
+	tests := []struct {
+		name  string
+		order KeyOrder
+		want  string
+	}{
+		{
+			name:  "Case 1",
+			order: KeyOrderSorted,
+			want: `<Details><Item key="alpha">2</Item><mid><Item key="x">2</Item><Item key="y">1</Item></mid><Item key="zeta">1</Item>` +
+				`<Item key="fields"><Field key="name">Required</Field><Field key="age">Too small</Field></Item></Details>`,
+		},
+		{
+			name:  "Case 2",
+			order: KeyOrderInsertion,
+			want: `<Details><Item key="zeta">1</Item><Item key="alpha">2</Item><mid><Item key="x">2</Item><Item key="y">1</Item></mid>` +
+				`<Item key="fields"><Field key="name">Required</Field><Field key="age">Too small</Field></Item></Details>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds = newOrderDetails().Order(tt.order)
+
+			for i := 0; i < 20; i++ {
+				var data, err = xml.Marshal(ds)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(data) != tt.want {
+					t.Fatalf("MarshalXML() got = %s, want %s", data, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetails_Order_Keys(t *testing.T) {
+	var ds = new(Details).Order(KeyOrderInsertion)
+
+	ds.Set("b", 1).Set("a", 2).Set("b", 3)
+
+	// Новые ключи переносятся в порядке упаковки источника.
+	var src = new(Details).Order(KeyOrderInsertion)
+
+	src.Set("d", 4).Set("c", 5).Set("a", 6)
+
+	if err := ds.Merge(src, MergeKeep); err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"b":3,"a":2,"d":4,"c":5}`
+
+	if data, _ := json.Marshal(ds); string(data) != want {
+		t.Errorf("MarshalJSON() got = %s, want %s", data, want)
+	}
+
+	if data, _ := json.Marshal(ds.Clone()); string(data) != want {
+		t.Errorf("Clone() MarshalJSON() got = %s, want %s", data, want)
+	}
+
+	ds.Reset()
+	ds.Set("z", 1).Set("y", 2)
+
+	if data, _ := json.Marshal(ds); string(data) != `{"z":1,"y":2}` {
+		t.Errorf("Reset() MarshalJSON() got = %s", data)
+	}
+}
+
+func TestSetDefaultKeyOrder(t *testing.T) {
+	defer SetDefaultKeyOrder(KeyOrderDefault)
+
+	SetDefaultKeyOrder(KeyOrderInsertion)
+
+	if got := DefaultKeyOrder(); got != KeyOrderInsertion {
+		t.Errorf("DefaultKeyOrder() = %v, want %v", got, KeyOrderInsertion)
+	}
+
+	var ds = new(Details)
+
+	ds.Set("b", 1).Set("a", 2)
+
+	if data, _ := json.Marshal(ds); string(data) != `{"b":1,"a":2}` {
+		t.Errorf("MarshalJSON() got = %s", data)
+	}
+
+	SetDefaultKeyOrder(KeyOrderDefault)
+
+	if got := DefaultKeyOrder(); got != KeyOrderSorted {
+		t.Errorf("DefaultKeyOrder() = %v, want %v", got, KeyOrderSorted)
+	}
+}
@@ -0,0 +1,234 @@
+package details
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sm-errors/types"
+	"sort"
+	"strings"
+)
+
+// MergeStrategy - стратегия разрешения конфликтов при слиянии деталей ошибки.
+// Конфликтом считается одинаковый ключ с разными значениями или сообщениями полей.
+type MergeStrategy uint8
+
+// Стратегии разрешения конфликтов.
+const (
+	// MergeKeep - сохранить существующее значение.
+	MergeKeep MergeStrategy = iota
+	// MergeOverwrite - заменить значение новым.
+	MergeOverwrite
+	// MergeCollect - собрать значения в список Collected, нарушения полей - в общий список.
+	MergeCollect
+	// MergeError - вернуть ошибку ErrMergeConflict без изменения деталей.
+	MergeError
+)
+
+// ErrMergeConflict - ошибка конфликта ключей при слиянии деталей.
+var ErrMergeConflict = errors.New("details: merge conflict")
+
+// Collected - значения одного ключа, собранные при слиянии со стратегией MergeCollect.
+type Collected []any
+
+// storageSnapshot - получение копии хранилища деталей и его ключей в порядке упаковки.
+// Хранилище доступно только для деталей типа *Details.
+func storageSnapshot(d types.Details) (storage map[string]any, keys []string) {
+	var ds, ok = d.(*Details)
+
+	if !ok || ds == nil {
+		return
+	}
+
+	ds.rwMux.RLock()
+	defer ds.rwMux.RUnlock()
+
+	storage = make(map[string]any, len(ds.storage))
+
+	for k, v := range ds.storage {
+		storage[k] = v
+	}
+
+	keys = ds.storageKeys()
+
+	return
+}
+
+// Merge - слияние деталей src в текущие детали со стратегией разрешения конфликтов.
+// Значения хранилища переносятся из деталей типа *Details, поля - из любых деталей.
+// Перенесенные значения и сообщения копируются. Со стратегией MergeError при конфликте
+// возвращается ошибка со списком конфликтующих ключей, детали не изменяются.
+func (ds *Details) Merge(src types.Details, strategy MergeStrategy) (err error) {
+	if src == nil || src == types.Details(ds) {
+		return
+	}
+
+	var (
+		storage, keys = storageSnapshot(src)
+		fields        = src.Fields()
+	)
+
+	ds.rwMux.Lock()
+	defer ds.rwMux.Unlock()
+
+	ds.init()
+
+	if strategy == MergeError {
+		if err = ds.mergeConflicts(storage, fields); err != nil {
+			return
+		}
+	}
+
+	// storage
+	{
+		for _, k := range keys {
+			var (
+				v               = storage[k]
+				current, exists = ds.storage[k]
+			)
+
+			if !exists {
+				ds.keys = append(ds.keys, k)
+			}
+
+			switch {
+			case !exists || strategy == MergeOverwrite || strategy == MergeError:
+				ds.storage[k] = cloneValue(v)
+			case strategy == MergeCollect && !reflect.DeepEqual(current, v):
+				{
+					var list, ok = current.(Collected)
+
+					if !ok {
+						list = Collected{current}
+					}
+
+					ds.storage[k] = append(list, cloneValue(v))
+				}
+			}
+		}
+	}
+
+	// fields
+	{
+		for _, f := range fields {
+			var (
+				field = cloneField(f)
+				found bool
+			)
+
+			for _, current := range ds.fields {
+				if !equalFieldKeys(current.Key, field.Key) {
+					continue
+				}
+
+				found = true
+
+				switch strategy {
+				case MergeOverwrite, MergeError:
+					{
+						current.Message = field.Message
+						current.Violations = field.Violations
+					}
+				case MergeCollect:
+					{
+						if !equalFields(current, field) {
+							current.Violations = append(fieldViolations(current), fieldViolations(field)...)
+						}
+
+						if current.Message == nil {
+							current.Message = field.Message
+						}
+					}
+				}
+
+				break
+			}
+
+			if !found {
+				ds.fields = append(ds.fields, field)
+			}
+		}
+	}
+
+	return
+}
+
+// mergeConflicts - поиск конфликтующих ключей хранилища и полей.
+func (ds *Details) mergeConflicts(storage map[string]any, fields []types.DetailsField) (err error) {
+	var conflicts []string
+
+	for k, v := range storage {
+		if current, exists := ds.storage[k]; exists && !reflect.DeepEqual(current, v) {
+			conflicts = append(conflicts, fmt.Sprintf("'%s'", k))
+		}
+	}
+
+	sort.Strings(conflicts)
+
+	for _, f := range fields {
+		for _, current := range ds.fields {
+			if equalFieldKeys(current.Key, f.Key) && !equalFields(current, &f) {
+				conflicts = append(conflicts, fmt.Sprintf("field '%s'", f.Key))
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %s", ErrMergeConflict, strings.Join(conflicts, ", "))
+	}
+
+	return
+}
+
+// cloneField - копирование поля ошибки.
+func cloneField(f types.DetailsField) (field *types.DetailsField) {
+	field = &types.DetailsField{
+		Key:        f.Key,
+		Violations: cloneViolations(f.Violations, true),
+	}
+
+	if f.Key != nil {
+		field.Key = f.Key.Clone()
+	}
+
+	if f.Message != nil {
+		field.Message = f.Message.Clone()
+	}
+
+	return
+}
+
+// fieldViolations - получение нарушений поля, для поля без нарушений - нарушения из его сообщения.
+func fieldViolations(f *types.DetailsField) (violations []types.DetailsFieldViolation) {
+	if len(f.Violations) > 0 || f.Message == nil {
+		return f.Violations
+	}
+
+	return []types.DetailsFieldViolation{{Message: f.Message}}
+}
+
+// equalFields - сравнение сообщений и нарушений полей по тексту, кодам и параметрам.
+func equalFields(a, b *types.DetailsField) (ok bool) {
+	if messageText(a.Message) != messageText(b.Message) || len(a.Violations) != len(b.Violations) {
+		return false
+	}
+
+	for i := range a.Violations {
+		var va, vb = a.Violations[i], b.Violations[i]
+
+		if va.Code != vb.Code || messageText(va.Message) != messageText(vb.Message) || !reflect.DeepEqual(va.Params, vb.Params) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// messageText - получение текста сообщения, для отсутствующего сообщения - пустая строка.
+func messageText(m types.Message) (text string) {
+	if m == nil {
+		return
+	}
+
+	return m.String()
+}
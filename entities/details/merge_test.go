@@ -0,0 +1,183 @@
+package details
+
+import (
+	"errors"
+	"reflect"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+)
+
+// newMergeDetails - детали ошибки со значениями и сообщениями полей для тестов слияния.
+func newMergeDetails(values map[string]any, fields map[string]string) *Details {
+	var ds = new(Details)
+
+	for k, v := range values {
+		ds.Set(k, v)
+	}
+
+	for k, text := range fields {
+		ds.SetField(new(FieldKey).Add(k), new(messages.TextMessage).Text(text))
+	}
+
+	return ds
+}
+
+func TestDetails_Merge(t *testing.T) {
+	type args struct {
+		dst      *Details
+		src      types.Details
+		strategy MergeStrategy
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantValues map[string]any
+		wantFields map[string]string
+		wantErr    error
+	}{
+		{
+			name: "Case 1",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": 1, "b": 2}, map[string]string{"name": "Old"}),
+				src:      newMergeDetails(map[string]any{"b": 3, "c": 4}, map[string]string{"name": "New", "email": "Invalid"}),
+				strategy: MergeKeep,
+			},
+			wantValues: map[string]any{"a": 1, "b": 2, "c": 4},
+			wantFields: map[string]string{"name": "Old", "email": "Invalid"},
+		},
+		{
+			name: "Case 2",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": 1, "b": 2}, map[string]string{"name": "Old"}),
+				src:      newMergeDetails(map[string]any{"b": 3, "c": 4}, map[string]string{"name": "New", "email": "Invalid"}),
+				strategy: MergeOverwrite,
+			},
+			wantValues: map[string]any{"a": 1, "b": 3, "c": 4},
+			wantFields: map[string]string{"name": "New", "email": "Invalid"},
+		},
+		{
+			name: "Case 3",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": 1, "b": 2}, nil),
+				src:      newMergeDetails(map[string]any{"a": 1, "b": 3}, nil),
+				strategy: MergeCollect,
+			},
+			wantValues: map[string]any{"a": 1, "b": Collected{2, 3}},
+			wantFields: map[string]string{},
+		},
+		{
+			name: "Case 4",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": 1, "b": 2}, map[string]string{"name": "Old"}),
+				src:      newMergeDetails(map[string]any{"b": 3, "c": 4}, map[string]string{"name": "New"}),
+				strategy: MergeError,
+			},
+			wantValues: map[string]any{"a": 1, "b": 2},
+			wantFields: map[string]string{"name": "Old"},
+			wantErr:    ErrMergeConflict,
+		},
+		{
+			name: "Case 5",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": []int{1}}, map[string]string{"name": "Same"}),
+				src:      newMergeDetails(map[string]any{"a": []int{1}, "c": 4}, map[string]string{"name": "Same"}),
+				strategy: MergeError,
+			},
+			wantValues: map[string]any{"a": []int{1}, "c": 4},
+			wantFields: map[string]string{"name": "Same"},
+		},
+		{
+			name: "Case 6",
+			args: args{
+				dst:      newMergeDetails(map[string]any{"a": 1}, nil),
+				src:      nil,
+				strategy: MergeOverwrite,
+			},
+			wantValues: map[string]any{"a": 1},
+			wantFields: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.args.dst.Merge(tt.args.src, tt.args.strategy); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got, _ := storageSnapshot(tt.args.dst); !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("Merge() values = %v, want %v", got, tt.wantValues)
+			}
+
+			var gotFields = make(map[string]string)
+
+			for _, f := range tt.args.dst.Fields() {
+				gotFields[f.Key.String()] = f.Message.String()
+			}
+
+			if !reflect.DeepEqual(gotFields, tt.wantFields) {
+				t.Errorf("Merge() fields = %v, want %v", gotFields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestDetails_Merge_CollectViolations(t *testing.T) {
+	var (
+		dst = newViolationsDetails()
+		src = new(Details)
+	)
+
+	src.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{
+		Code:    "required",
+		Message: new(messages.TextMessage).Text("Required"),
+	})
+
+	src.SetField(new(FieldKey).Add("login"), new(messages.TextMessage).Text("Taken"))
+
+	if err := dst.Merge(src, MergeCollect); err != nil {
+		t.Fatal(err)
+	}
+
+	var codes []string
+
+	for _, v := range dst.PeekFieldViolations("password") {
+		codes = append(codes, v.Code)
+	}
+
+	if want := []string{"min_length", "pattern", "required"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("Merge() violation codes = %v, want %v", codes, want)
+	}
+
+	if got := dst.PeekFieldMessage("password").String(); got != "Too short" {
+		t.Errorf("Merge() message = %q, want %q", got, "Too short")
+	}
+
+	if got := dst.PeekFieldMessage("login"); got == nil || got.String() != "Taken" {
+		t.Errorf("Merge() login message = %v, want %q", got, "Taken")
+	}
+
+	// Повторное слияние не добавляет нарушения к независимой копии источника.
+	src.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{Code: "email"})
+
+	if got := len(dst.PeekFieldViolations("password")); got != 3 {
+		t.Errorf("Merge() shares violations with source, got %d violations", got)
+	}
+}
+
+func TestDetails_Merge_Self(t *testing.T) {
+	var ds = newMergeDetails(map[string]any{"a": 1}, map[string]string{"name": "Invalid"})
+
+	if err := ds.Merge(ds, MergeCollect); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ds.Peek("a"); got != 1 {
+		t.Errorf("Merge() value = %v, want 1", got)
+	}
+
+	if got := ds.Fields(); len(got) != 1 {
+		t.Errorf("Merge() fields = %v, want 1 field", got)
+	}
+}
@@ -0,0 +1,39 @@
+package details
+
+import "strings"
+
+// Зарезервированные ключи упаковки деталей: под ними передаются сообщения и нарушения правил полей.
+// Значения хранилища с такими ключами упаковываются под экранированными ключами, см. EscapeKey.
+const (
+	KeyFields     = "fields"
+	KeyViolations = "violations"
+)
+
+// EscapeKey - получение ключа значения хранилища при упаковке.
+// Зарезервированные ключи и ключи вида "_fields", "__violations" дополняются символом "_" в начале,
+// поэтому значения хранилища не пересекаются с полями и нарушениями и восстанавливаются при распаковке.
+//
+//	EscapeKey("fields")  // "_fields"
+//	EscapeKey("_fields") // "__fields"
+//	EscapeKey("user_id") // "user_id"
+func EscapeKey(k string) (key string) {
+	if isReservedKey(strings.TrimLeft(k, "_")) {
+		return "_" + k
+	}
+
+	return k
+}
+
+// UnescapeKey - получение ключа значения хранилища при распаковке, обратно EscapeKey.
+func UnescapeKey(key string) (k string) {
+	if strings.HasPrefix(key, "_") && isReservedKey(strings.TrimLeft(key, "_")) {
+		return key[1:]
+	}
+
+	return key
+}
+
+// isReservedKey - проверка, зарезервирован ли ключ.
+func isReservedKey(k string) (ok bool) {
+	return k == KeyFields || k == KeyViolations
+}
@@ -0,0 +1,381 @@
+package details
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"sm-errors/types"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaType - тип значения ключа деталей в схеме.
+type SchemaType string
+
+// Типы значений ключей деталей.
+const (
+	SchemaString SchemaType = "string"
+	SchemaInt    SchemaType = "int"
+	SchemaFloat  SchemaType = "float"
+	SchemaBool   SchemaType = "bool"
+	SchemaAny    SchemaType = "any"
+)
+
+// Коды нарушений схемы деталей.
+const (
+	// SchemaViolationRequired - отсутствует обязательный ключ.
+	SchemaViolationRequired = "required"
+	// SchemaViolationType - значение ключа имеет другой тип.
+	SchemaViolationType = "type"
+	// SchemaViolationUnknown - ключ не описан в строгой схеме.
+	SchemaViolationUnknown = "unknown"
+)
+
+// Ошибки схемы деталей.
+var (
+	ErrInvalidSchema   = errors.New("details: invalid schema")
+	ErrSchemaViolation = errors.New("details: schema violation")
+)
+
+type (
+	// Schema - схема деталей ошибки: ключи хранилища, типы их значений и обязательность.
+	// Схема не изменяется после передачи в конструктор ошибки.
+	Schema struct {
+		Keys []SchemaKey
+
+		// Strict - ключи, не описанные в схеме, считаются нарушением.
+		// Проверяется только для деталей типа *Details.
+		Strict bool
+	}
+
+	// SchemaKey - описание ключа деталей в схеме.
+	SchemaKey struct {
+		Key         string
+		Type        SchemaType
+		Required    bool
+		Description string
+	}
+
+	// SchemaViolation - нарушение схемы деталей.
+	SchemaViolation struct {
+		Key      string
+		Code     string
+		Expected SchemaType
+		Got      string
+	}
+
+	// SchemaError - ошибка проверки деталей по схеме со списком нарушений.
+	SchemaError struct {
+		Violations []SchemaViolation
+	}
+
+	// jsonSchema - описание схемы в формате JSON Schema.
+	jsonSchema struct {
+		Schema               string                 `json:"$schema,omitempty"`
+		Title                string                 `json:"title,omitempty"`
+		Description          string                 `json:"description,omitempty"`
+		Type                 string                 `json:"type,omitempty"`
+		Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+		Required             []string               `json:"required,omitempty"`
+		AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	}
+)
+
+// schemaHandler - обработчик нарушений схемы деталей.
+var schemaHandler = struct {
+	fn    func(err error)
+	rwMux *sync.RWMutex
+}{
+	fn:    LogSchemaViolation,
+	rwMux: new(sync.RWMutex),
+}
+
+// SetSchemaHandler - установить обработчик нарушений схемы деталей, обнаруженных при упаковке ошибок.
+// По умолчанию нарушения записываются в журнал, см. LogSchemaViolation; nil отключает обработку.
+//
+//	details.SetSchemaHandler(func(err error) { t.Error(err) })
+func SetSchemaHandler(fn func(err error)) {
+	schemaHandler.rwMux.Lock()
+	defer schemaHandler.rwMux.Unlock()
+
+	schemaHandler.fn = fn
+}
+
+// ReportSchemaViolation - передать нарушение схемы деталей обработчику, см. SetSchemaHandler.
+func ReportSchemaViolation(err error) {
+	if err == nil {
+		return
+	}
+
+	schemaHandler.rwMux.RLock()
+	var fn = schemaHandler.fn
+	schemaHandler.rwMux.RUnlock()
+
+	if fn != nil {
+		fn(err)
+	}
+
+	return
+}
+
+// LogSchemaViolation - обработчик, записывающий нарушение схемы в журнал slog с уровнем Warn.
+func LogSchemaViolation(err error) {
+	slog.Warn("details schema violation", "error", err)
+}
+
+// PanicOnSchemaViolation - обработчик, вызывающий панику при нарушении схемы.
+func PanicOnSchemaViolation(err error) {
+	panic(err)
+}
+
+// Validate - проверка описания схемы: ключи не пустые и не повторяются, типы известны.
+func (s *Schema) Validate() (err error) {
+	var (
+		errs []error
+		keys = make(map[string]bool, len(s.Keys))
+	)
+
+	for i, k := range s.Keys {
+		if k.Key == "" {
+			errs = append(errs, fmt.Errorf("%w: keys[%d]: key is empty", ErrInvalidSchema, i))
+		} else if keys[k.Key] {
+			errs = append(errs, fmt.Errorf("%w: keys[%d]: duplicate key '%s'", ErrInvalidSchema, i, k.Key))
+		}
+
+		keys[k.Key] = true
+
+		switch k.Type {
+		case SchemaString, SchemaInt, SchemaFloat, SchemaBool, SchemaAny:
+		default:
+			errs = append(errs, fmt.Errorf("%w: keys[%d]: unknown type '%s'", ErrInvalidSchema, i, k.Type))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check - проверка деталей по схеме: обязательные ключи, типы значений и, для строгой схемы, лишние ключи.
+// При нарушениях возвращается *SchemaError, совместимая с ErrSchemaViolation.
+func (s *Schema) Check(d types.Details) (err error) {
+	return s.check(d, true)
+}
+
+// CheckValues - проверка деталей по схеме без проверки обязательных ключей.
+// Используется для деталей по умолчанию, которые дополняются после построения ошибки.
+func (s *Schema) CheckValues(d types.Details) (err error) {
+	return s.check(d, false)
+}
+
+// check - проверка деталей по схеме.
+func (s *Schema) check(d types.Details, required bool) (err error) {
+	var violations []SchemaViolation
+
+	for _, k := range s.Keys {
+		var v any
+
+		if d != nil {
+			v = d.Peek(k.Key)
+		}
+
+		if v == nil {
+			if required && k.Required {
+				violations = append(violations, SchemaViolation{
+					Key:      k.Key,
+					Code:     SchemaViolationRequired,
+					Expected: k.Type,
+				})
+			}
+
+			continue
+		}
+
+		if !k.Type.match(v) {
+			violations = append(violations, SchemaViolation{
+				Key:      k.Key,
+				Code:     SchemaViolationType,
+				Expected: k.Type,
+				Got:      fmt.Sprintf("%T", v),
+			})
+		}
+	}
+
+	if s.Strict && d != nil {
+		var (
+			storage, _ = storageSnapshot(d)
+			unknown    []string
+		)
+
+		for key := range storage {
+			if !s.has(key) {
+				unknown = append(unknown, key)
+			}
+		}
+
+		sort.Strings(unknown)
+
+		for _, key := range unknown {
+			violations = append(violations, SchemaViolation{
+				Key:  key,
+				Code: SchemaViolationUnknown,
+				Got:  fmt.Sprintf("%T", storage[key]),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+
+	return
+}
+
+// has - проверка, описан ли ключ в схеме.
+func (s *Schema) has(key string) (ok bool) {
+	for _, k := range s.Keys {
+		if k.Key == key {
+			return true
+		}
+	}
+
+	return
+}
+
+// Clone - копирование схемы.
+func (s *Schema) Clone() (s_ *Schema) {
+	if s == nil {
+		return
+	}
+
+	s_ = &Schema{
+		Keys:   append([]SchemaKey(nil), s.Keys...),
+		Strict: s.Strict,
+	}
+
+	return
+}
+
+// JSONSchema - получение схемы деталей в формате JSON Schema (draft 2020-12).
+// Кроме ключей схемы описываются объекты "fields" и "violations" с полями ошибки,
+// зарезервированные ключи схемы описываются под экранированными ключами, см. EscapeKey.
+func (s *Schema) JSONSchema(title string) (data []byte, err error) {
+	var (
+		object = &jsonSchema{
+			Schema:     "https://json-schema.org/draft/2020-12/schema",
+			Title:      title,
+			Type:       "object",
+			Properties: make(map[string]*jsonSchema, len(s.Keys)+2),
+		}
+		nested = &jsonSchema{Type: "object"}
+	)
+
+	for _, k := range s.Keys {
+		object.Properties[EscapeKey(k.Key)] = &jsonSchema{
+			Type:        k.Type.jsonType(),
+			Description: k.Description,
+		}
+
+		if k.Required {
+			object.Required = append(object.Required, EscapeKey(k.Key))
+		}
+	}
+
+	object.Properties[KeyFields] = nested
+	object.Properties[KeyViolations] = nested
+
+	if s.Strict {
+		var additional = false
+		object.AdditionalProperties = &additional
+	}
+
+	return json.Marshal(object)
+}
+
+// jsonType - получение типа JSON Schema, для SchemaAny - пустая строка.
+func (t SchemaType) jsonType() (str string) {
+	switch t {
+	case SchemaString:
+		return "string"
+	case SchemaInt:
+		return "integer"
+	case SchemaFloat:
+		return "number"
+	case SchemaBool:
+		return "boolean"
+	}
+
+	return
+}
+
+// match - проверка соответствия значения типу.
+// Целые значения, распакованные из JSON как float64, соответствуют типу SchemaInt.
+func (t SchemaType) match(v any) (ok bool) {
+	var rv = reflect.ValueOf(v)
+
+	switch t {
+	case SchemaString:
+		return rv.Kind() == reflect.String
+	case SchemaBool:
+		return rv.Kind() == reflect.Bool
+	case SchemaInt:
+		{
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				return true
+			case reflect.Float32, reflect.Float64:
+				return rv.Float() == math.Trunc(rv.Float())
+			}
+
+			return
+		}
+	case SchemaFloat:
+		{
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				return true
+			}
+
+			return
+		}
+	case SchemaAny:
+		return true
+	}
+
+	return
+}
+
+// Error - получение текста ошибки со списком нарушений.
+func (e *SchemaError) Error() (s string) {
+	var list = make([]string, 0, len(e.Violations))
+
+	for _, v := range e.Violations {
+		list = append(list, v.String())
+	}
+
+	return ErrSchemaViolation.Error() + ": " + strings.Join(list, "; ")
+}
+
+// Unwrap - получение базовой ошибки ErrSchemaViolation.
+func (e *SchemaError) Unwrap() (err error) {
+	return ErrSchemaViolation
+}
+
+// String - получение текста нарушения.
+func (v SchemaViolation) String() (s string) {
+	switch v.Code {
+	case SchemaViolationRequired:
+		return fmt.Sprintf("'%s': required key is missing", v.Key)
+	case SchemaViolationType:
+		return fmt.Sprintf("'%s': expected %s, got %s", v.Key, v.Expected, v.Got)
+	case SchemaViolationUnknown:
+		return fmt.Sprintf("'%s': key is not declared in schema", v.Key)
+	}
+
+	return fmt.Sprintf("'%s': %s", v.Key, v.Code)
+}
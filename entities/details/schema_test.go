@@ -0,0 +1,216 @@
+package details
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newTestSchema - схема деталей для тестов.
+func newTestSchema(strict bool) *Schema {
+	return &Schema{
+		Keys: []SchemaKey{
+			{Key: "user_id", Type: SchemaInt, Required: true, Description: "User identifier"},
+			{Key: "reason", Type: SchemaString},
+			{Key: "ratio", Type: SchemaFloat},
+			{Key: "retry", Type: SchemaBool},
+			{Key: "extra", Type: SchemaAny},
+		},
+		Strict: strict,
+	}
+}
+
+func TestSchema_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		values map[string]any
+		want   []SchemaViolation
+	}{
+		{
+			name:   "Case 1",
+			schema: newTestSchema(false),
+			values: map[string]any{"user_id": 7, "reason": "blocked", "ratio": 1, "retry": true, "extra": []int{1}},
+			want:   nil,
+		},
+		{
+			name:   "Case 2",
+			schema: newTestSchema(false),
+			values: map[string]any{"reason": "blocked"},
+			want: []SchemaViolation{
+				{Key: "user_id", Code: SchemaViolationRequired, Expected: SchemaInt},
+			},
+		},
+		{
+			name:   "Case 3",
+			schema: newTestSchema(false),
+			values: map[string]any{"user_id": "7", "ratio": "0.5", "retry": 1},
+			want: []SchemaViolation{
+				{Key: "user_id", Code: SchemaViolationType, Expected: SchemaInt, Got: "string"},
+				{Key: "ratio", Code: SchemaViolationType, Expected: SchemaFloat, Got: "string"},
+				{Key: "retry", Code: SchemaViolationType, Expected: SchemaBool, Got: "int"},
+			},
+		},
+		{
+			name:   "Case 4",
+			schema: newTestSchema(false),
+			values: map[string]any{"user_id": float64(7)},
+			want:   nil,
+		},
+		{
+			name:   "Case 5",
+			schema: newTestSchema(false),
+			values: map[string]any{"user_id": 7.5},
+			want: []SchemaViolation{
+				{Key: "user_id", Code: SchemaViolationType, Expected: SchemaInt, Got: "float64"},
+			},
+		},
+		{
+			name:   "Case 6",
+			schema: newTestSchema(true),
+			values: map[string]any{"user_id": 7, "trace": "abc", "attempt": 1},
+			want: []SchemaViolation{
+				{Key: "attempt", Code: SchemaViolationUnknown, Got: "int"},
+				{Key: "trace", Code: SchemaViolationUnknown, Got: "string"},
+			},
+		},
+		{
+			name:   "Case 7",
+			schema: newTestSchema(false),
+			values: map[string]any{"user_id": 7, "trace": "abc"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds = new(Details)
+
+			for k, v := range tt.values {
+				ds.Set(k, v)
+			}
+
+			var err = tt.schema.Check(ds)
+
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Check() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var schemaErr *SchemaError
+
+			if !errors.As(err, &schemaErr) || !errors.Is(err, ErrSchemaViolation) {
+				t.Fatalf("Check() error = %v, want *SchemaError", err)
+			}
+
+			if !reflect.DeepEqual(schemaErr.Violations, tt.want) {
+				t.Errorf("Check() violations = %v, want %v", schemaErr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_CheckValues(t *testing.T) {
+	var schema = newTestSchema(false)
+
+	if err := schema.CheckValues(new(Details)); err != nil {
+		t.Errorf("CheckValues() error = %v, want nil", err)
+	}
+
+	if err := schema.CheckValues(new(Details).Set("user_id", "7")); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("CheckValues() error = %v, want %v", err, ErrSchemaViolation)
+	}
+
+	if err := schema.Check(nil); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("Check() error = %v, want %v", err, ErrSchemaViolation)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *Schema
+		wantErr bool
+	}{
+		{name: "Case 1", schema: newTestSchema(true), wantErr: false},
+		{name: "Case 2", schema: &Schema{Keys: []SchemaKey{{Key: "", Type: SchemaString}}}, wantErr: true},
+		{name: "Case 3", schema: &Schema{Keys: []SchemaKey{{Key: "a", Type: SchemaString}, {Key: "a", Type: SchemaInt}}}, wantErr: true},
+		{name: "Case 4", schema: &Schema{Keys: []SchemaKey{{Key: "a", Type: "uuid"}}}, wantErr: true},
+		{name: "Case 5", schema: new(Schema), wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err = tt.schema.Validate()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidSchema)
+			}
+		})
+	}
+}
+
+func TestSchema_JSONSchema(t *testing.T) {
+	var data, err = newTestSchema(true).JSONSchema("USER_BLOCKED")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"USER_BLOCKED","type":"object",` +
+		`"properties":{"extra":{},"fields":{"type":"object"},"ratio":{"type":"number"},` +
+		`"reason":{"type":"string"},"retry":{"type":"boolean"},` +
+		`"user_id":{"description":"User identifier","type":"integer"},"violations":{"type":"object"}},` +
+		`"required":["user_id"],"additionalProperties":false}`
+
+	if string(data) != want {
+		t.Errorf("JSONSchema() = %s, want %s", data, want)
+	}
+}
+
+func TestSetSchemaHandler(t *testing.T) {
+	defer SetSchemaHandler(LogSchemaViolation)
+
+	var reported []error
+
+	SetSchemaHandler(func(err error) {
+		reported = append(reported, err)
+	})
+
+	ReportSchemaViolation(nil)
+	ReportSchemaViolation(newTestSchema(false).Check(new(Details)))
+
+	if len(reported) != 1 || !errors.Is(reported[0], ErrSchemaViolation) {
+		t.Errorf("ReportSchemaViolation() reported = %v, want 1 violation", reported)
+	}
+
+	var want = "details: schema violation: 'user_id': required key is missing"
+
+	if got := reported[0].Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	SetSchemaHandler(nil)
+	ReportSchemaViolation(newTestSchema(false).Check(new(Details)))
+
+	if len(reported) != 1 {
+		t.Errorf("ReportSchemaViolation() with nil handler reported = %v", reported)
+	}
+
+	SetSchemaHandler(PanicOnSchemaViolation)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ReportSchemaViolation() did not panic")
+		}
+	}()
+
+	ReportSchemaViolation(newTestSchema(false).Check(new(Details)))
+}
@@ -0,0 +1,109 @@
+package details
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+// newViolationsDetails - детали ошибки с нарушениями правил поля для тестов.
+func newViolationsDetails() *Details {
+	var ds = new(Details)
+
+	ds.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{
+		Code:    "min_length",
+		Params:  map[string]any{"min": 8},
+		Message: new(messages.TextMessage).Text("Too short"),
+	})
+
+	ds.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{
+		Code:    "pattern",
+		Message: new(messages.TextMessage).Text("No digit"),
+	})
+
+	return ds
+}
+
+func TestDetails_AddFieldViolation(t *testing.T) {
+	var ds = newViolationsDetails()
+
+	var want = []types.DetailsFieldViolation{
+		{
+			Code:    "min_length",
+			Params:  map[string]any{"min": 8},
+			Message: new(messages.TextMessage).Text("Too short"),
+		},
+		{
+			Code:    "pattern",
+			Message: new(messages.TextMessage).Text("No digit"),
+		},
+	}
+
+	if got := ds.PeekFieldViolations("password"); !reflect.DeepEqual(got, want) {
+		t.Errorf("PeekFieldViolations() = %v, want %v", got, want)
+	}
+
+	if got := ds.PeekFieldMessage("password"); !reflect.DeepEqual(got, want[0].Message) {
+		t.Errorf("PeekFieldMessage() = %v, want %v", got, want[0].Message)
+	}
+
+	if got := ds.Fields(); len(got) != 1 || len(got[0].Violations) != 2 {
+		t.Errorf("Fields() = %v, want 1 field with 2 violations", got)
+	}
+
+	// Копия деталей не разделяет нарушения с исходными деталями.
+	var c = ds.Clone()
+	c.AddFieldViolation(new(FieldKey).Add("password"), types.DetailsFieldViolation{Code: "required"})
+
+	if got := len(ds.PeekFieldViolations("password")); got != 2 {
+		t.Errorf("Clone() shares violations, got %d violations", got)
+	}
+
+	// Установка сообщения поля сбрасывает нарушения.
+	ds.SetField(new(FieldKey).Add("password"), new(messages.TextMessage).Text("Invalid"))
+
+	if got := ds.PeekFieldViolations("password"); got != nil {
+		t.Errorf("SetField() violations = %v, want nil", got)
+	}
+}
+
+func TestDetails_MarshalJSON_Violations(t *testing.T) {
+	var data, err = json.Marshal(newViolationsDetails())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"fields":{"password":"Too short"},"violations":{"password":[` +
+		`{"code":"min_length","params":{"min":8},"message":"Too short"},` +
+		`{"code":"pattern","message":"No digit"}]}}`
+
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+}
+
+func TestDetails_MarshalXML_Violations(t *testing.T) {
+	var data, err = xml.Marshal(newViolationsDetails())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Порядок элементов деталей не определен, проверяются блоки полей и нарушений.
+	for _, want := range []string{
+		`<Item key="fields"><Field key="password">Too short</Field></Item>`,
+		`<Item key="violations"><Field key="password">` +
+			`<Violation code="min_length"><Param name="min">8</Param><Message>Too short</Message></Violation>` +
+			`<Violation code="pattern"><Message>No digit</Message></Violation>` +
+			`</Field></Item>`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("MarshalXML() = %s, want to contain %s", data, want)
+		}
+	}
+}
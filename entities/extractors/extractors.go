@@ -0,0 +1,27 @@
+package extractors
+
+import (
+	"context"
+	"sm-errors/types"
+	"time"
+)
+
+// Value - перенос значения контекста по ключу в детали ошибки.
+// Значение не переносится, если в контексте оно отсутствует.
+func Value(key any, name string) types.ContextExtractor {
+	return func(ctx context.Context, d types.Details) {
+		if v := ctx.Value(key); v != nil {
+			d.Set(name, v)
+		}
+	}
+}
+
+// Deadline - перенос оставшегося до крайнего срока контекста времени в миллисекундах в детали ошибки.
+// Значение не переносится, если у контекста нет крайнего срока.
+func Deadline(name string) types.ContextExtractor {
+	return func(ctx context.Context, d types.Details) {
+		if deadline, ok := ctx.Deadline(); ok {
+			d.Set(name, time.Until(deadline).Milliseconds())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package extractors
+
+import (
+	"context"
+	"reflect"
+	"sm-errors/entities/details"
+	"testing"
+	"time"
+)
+
+type testContextKey string
+
+func TestValue(t *testing.T) {
+	type args struct {
+		key  any
+		name string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		ctx  context.Context
+		want any
+	}{
+		{
+			name: "Case 1",
+			args: args{
+				key:  testContextKey("request_id"),
+				name: "request_id",
+			},
+			ctx:  context.WithValue(context.Background(), testContextKey("request_id"), "R-1"),
+			want: "R-1",
+		},
+		{
+			name: "Case 2",
+			args: args{
+				key:  testContextKey("request_id"),
+				name: "request_id",
+			},
+			ctx:  context.Background(),
+			want: nil,
+		},
+		{
+			name: "Case 3",
+			args: args{
+				key:  testContextKey("user_id"),
+				name: "user",
+			},
+			ctx:  context.WithValue(context.Background(), testContextKey("user_id"), 42),
+			want: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d = new(details.Details)
+
+			Value(tt.args.key, tt.args.name)(tt.ctx, d)
+
+			if got := d.Peek(tt.args.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	var d = new(details.Details)
+
+	Deadline("deadline")(context.Background(), d)
+
+	if got := d.Peek("deadline"); got != nil {
+		t.Errorf("Deadline() = %v, want nil", got)
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	Deadline("deadline")(ctx, d)
+
+	if got, ok := d.Peek("deadline").(int64); !ok || got <= 0 || got > time.Minute.Milliseconds() {
+		t.Errorf("Deadline() = %v, want remaining milliseconds", d.Peek("deadline"))
+	}
+}
@@ -0,0 +1,281 @@
+package messages
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// Bundle - набор переводов сообщений по языкам с цепочками запасных языков.
+	// Нулевое значение готово к использованию: набор без языка по умолчанию.
+	Bundle struct {
+		defaultLocale string
+
+		translations map[string]map[string]string
+		fallbacks    map[string][]string
+		rwMux        sync.RWMutex
+	}
+
+	// bundleWrapper - структура обертка для упаковки набора переводов.
+	bundleWrapper struct {
+		DefaultLocale string                       `json:"default_locale"`
+		Fallbacks     map[string][]string          `json:"fallbacks,omitempty"`
+		Translations  map[string]map[string]string `json:"translations"`
+	}
+)
+
+// NewBundle - создание набора переводов с языком по умолчанию.
+// Язык по умолчанию завершает любую цепочку поиска перевода.
+func NewBundle(defaultLocale string) (b *Bundle) {
+	b = &Bundle{
+		defaultLocale: NormalizeLocale(defaultLocale),
+
+		translations: make(map[string]map[string]string),
+		fallbacks:    make(map[string][]string),
+	}
+
+	return
+}
+
+// init - инициализация набора переводов, вызывается под блокировкой на запись.
+// Методы чтения работают и с неинициализированными картами.
+func (b *Bundle) init() {
+	if b.translations == nil {
+		b.translations = make(map[string]map[string]string)
+	}
+
+	if b.fallbacks == nil {
+		b.fallbacks = make(map[string][]string)
+	}
+
+	return
+}
+
+// Add - добавить переводы сообщений для языка.
+// Существующие переводы с теми же ключами заменяются.
+func (b *Bundle) Add(locale string, translations map[string]string) *Bundle {
+	b.rwMux.Lock()
+	defer b.rwMux.Unlock()
+
+	b.init()
+
+	locale = NormalizeLocale(locale)
+
+	if b.translations[locale] == nil {
+		b.translations[locale] = make(map[string]string, len(translations))
+	}
+
+	for k, v := range translations {
+		b.translations[locale][k] = v
+	}
+
+	return b
+}
+
+// SetFallback - установить цепочку запасных языков для языка, например kk -> ru -> en.
+func (b *Bundle) SetFallback(locale string, chain ...string) *Bundle {
+	b.rwMux.Lock()
+	defer b.rwMux.Unlock()
+
+	b.init()
+
+	var list = make([]string, 0, len(chain))
+
+	for _, l := range chain {
+		list = append(list, NormalizeLocale(l))
+	}
+
+	b.fallbacks[NormalizeLocale(locale)] = list
+
+	return b
+}
+
+// DefaultLocale - получение языка по умолчанию.
+func (b *Bundle) DefaultLocale() (locale string) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	return b.defaultLocale
+}
+
+// Locales - получение отсортированного списка языков, для которых есть переводы.
+func (b *Bundle) Locales() (list []string) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	for locale := range b.translations {
+		list = append(list, locale)
+	}
+
+	sort.Strings(list)
+
+	return
+}
+
+// Keys - получение отсортированного списка ключей сообщений для языка.
+func (b *Bundle) Keys(locale string) (list []string) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	for key := range b.translations[NormalizeLocale(locale)] {
+		list = append(list, key)
+	}
+
+	sort.Strings(list)
+
+	return
+}
+
+// Text - получение перевода сообщения для предпочтительных языков.
+// Для каждого языка проверяются сам язык, его базовый язык и цепочка запасных языков,
+// в конце проверяется язык по умолчанию. Возвращается язык найденного перевода.
+func (b *Bundle) Text(key string, locales ...string) (text string, locale string, ok bool) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	for _, candidate := range b.candidates(locales) {
+		if text, ok = b.translations[candidate][key]; ok {
+			locale = candidate
+			return
+		}
+	}
+
+	return
+}
+
+// Plural - получение перевода сообщения с учетом формы множественного числа.
+// Для каждого языка категория выбирается по его правилам, перевод ищется по ключу
+// формы (см. PluralKey), затем по ключу формы PluralOther и по самому ключу.
+func (b *Bundle) Plural(key string, n any, locales ...string) (text string, locale string, ok bool) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	for _, candidate := range b.candidates(locales) {
+		var translations = b.translations[candidate]
+
+		for _, k := range []string{PluralKey(key, PluralCategoryOf(candidate, n)), PluralKey(key, PluralOther), key} {
+			if text, ok = translations[k]; ok {
+				locale = candidate
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// PluralKey - получение ключа перевода для формы множественного числа, например "fields.invalid#few".
+func PluralKey(key string, c PluralCategory) (str string) {
+	return key + "#" + string(c)
+}
+
+// Negotiate - выбор языка набора переводов для предпочтительных языков.
+// Если ни один язык не подходит, возвращается язык по умолчанию.
+func (b *Bundle) Negotiate(locales ...string) (locale string) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	for _, candidate := range b.candidates(locales) {
+		if _, ok := b.translations[candidate]; ok {
+			return candidate
+		}
+	}
+
+	return b.defaultLocale
+}
+
+// MarshalJSON - упаковать в формат JSON.
+func (b *Bundle) MarshalJSON() ([]byte, error) {
+	b.rwMux.RLock()
+	defer b.rwMux.RUnlock()
+
+	var w = &bundleWrapper{
+		DefaultLocale: b.defaultLocale,
+		Translations:  b.translations,
+	}
+
+	if w.Translations == nil {
+		w.Translations = make(map[string]map[string]string)
+	}
+
+	if len(b.fallbacks) > 0 {
+		w.Fallbacks = b.fallbacks
+	}
+
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON - распаковать из формата JSON.
+// Переводы и цепочки запасных языков добавляются к уже существующим.
+func (b *Bundle) UnmarshalJSON(data []byte) (err error) {
+	var w = new(bundleWrapper)
+
+	if err = json.Unmarshal(data, w); err != nil {
+		return
+	}
+
+	if w.DefaultLocale != "" {
+		b.rwMux.Lock()
+		b.defaultLocale = NormalizeLocale(w.DefaultLocale)
+		b.rwMux.Unlock()
+	}
+
+	for locale, translations := range w.Translations {
+		b.Add(locale, translations)
+	}
+
+	for locale, chain := range w.Fallbacks {
+		b.SetFallback(locale, chain...)
+	}
+
+	return
+}
+
+// candidates - получение упорядоченного списка языков для поиска перевода.
+func (b *Bundle) candidates(locales []string) (list []string) {
+	var seen = make(map[string]bool)
+
+	var add func(locale string)
+
+	add = func(locale string) {
+		if locale == "" || seen[locale] {
+			return
+		}
+
+		seen[locale] = true
+		list = append(list, locale)
+
+		for _, fallback := range b.fallbacks[locale] {
+			add(fallback)
+		}
+	}
+
+	for _, locale := range locales {
+		locale = NormalizeLocale(locale)
+
+		add(locale)
+		add(BaseLocale(locale))
+	}
+
+	add(b.defaultLocale)
+
+	return
+}
+
+// NormalizeLocale - приведение обозначения языка к виду "ru" или "kk-kz".
+func NormalizeLocale(locale string) (str string) {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// BaseLocale - получение базового языка, например "kk" для "kk-kz".
+func BaseLocale(locale string) (str string) {
+	str = NormalizeLocale(locale)
+
+	if i := strings.IndexByte(str, '-'); i > 0 {
+		str = str[:i]
+	}
+
+	return
+}
@@ -0,0 +1,275 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// newTestBundle - создание набора переводов для тестов.
+func newTestBundle() (b *Bundle) {
+	return NewBundle("en").
+		Add("en", map[string]string{
+			"user.not_found": "User not found. ",
+			"user.blocked":   "User is blocked. ",
+		}).
+		Add("ru", map[string]string{
+			"user.not_found": "Пользователь не найден. ",
+		}).
+		Add("kk", map[string]string{}).
+		SetFallback("kk", "ru", "en")
+}
+
+func TestBundle_Text(t *testing.T) {
+	type args struct {
+		key     string
+		locales []string
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantText   string
+		wantLocale string
+		wantOk     bool
+	}{
+		{
+			name: "Case 1",
+			args: args{
+				key:     "user.not_found",
+				locales: []string{"ru"},
+			},
+			wantText:   "Пользователь не найден. ",
+			wantLocale: "ru",
+			wantOk:     true,
+		},
+		{
+			name: "Case 2",
+			args: args{
+				key:     "user.not_found",
+				locales: []string{"kk-KZ"},
+			},
+			wantText:   "Пользователь не найден. ",
+			wantLocale: "ru",
+			wantOk:     true,
+		},
+		{
+			name: "Case 3",
+			args: args{
+				key:     "user.blocked",
+				locales: []string{"kk"},
+			},
+			wantText:   "User is blocked. ",
+			wantLocale: "en",
+			wantOk:     true,
+		},
+		{
+			name: "Case 4",
+			args: args{
+				key:     "user.not_found",
+				locales: []string{"de", "fr"},
+			},
+			wantText:   "User not found. ",
+			wantLocale: "en",
+			wantOk:     true,
+		},
+		{
+			name: "Case 5",
+			args: args{
+				key:     "unknown",
+				locales: []string{"ru"},
+			},
+			wantText:   "",
+			wantLocale: "",
+			wantOk:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotText, gotLocale, gotOk := newTestBundle().Text(tt.args.key, tt.args.locales...)
+
+			if gotText != tt.wantText || gotLocale != tt.wantLocale || gotOk != tt.wantOk {
+				t.Errorf("Text() = %v, %v, %v, want %v, %v, %v", gotText, gotLocale, gotOk, tt.wantText, tt.wantLocale, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBundle_Negotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    string
+	}{
+		{
+			name:    "Case 1",
+			locales: []string{"ru-RU", "en"},
+			want:    "ru",
+		},
+		{
+			name:    "Case 2",
+			locales: []string{"de"},
+			want:    "en",
+		},
+		{
+			name:    "Case 3",
+			locales: []string{"KK_kz"},
+			want:    "kk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestBundle().Negotiate(tt.locales...); got != tt.want {
+				t.Errorf("Negotiate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundle_Locales(t *testing.T) {
+	var b = newTestBundle()
+
+	if got := b.Locales(); !reflect.DeepEqual(got, []string{"en", "kk", "ru"}) {
+		t.Errorf("Locales() = %v, want %v", got, []string{"en", "kk", "ru"})
+	}
+
+	if got := b.Keys("en"); !reflect.DeepEqual(got, []string{"user.blocked", "user.not_found"}) {
+		t.Errorf("Keys() = %v, want %v", got, []string{"user.blocked", "user.not_found"})
+	}
+}
+
+func TestBundle_Plural(t *testing.T) {
+	var b = NewBundle("en").
+		Add("en", map[string]string{
+			"fields.invalid#one":   "{count} field is invalid",
+			"fields.invalid#other": "{count} fields are invalid",
+		}).
+		Add("ru", map[string]string{
+			"fields.invalid#one":  "{count} поле заполнено неверно",
+			"fields.invalid#few":  "{count} поля заполнены неверно",
+			"fields.invalid#many": "{count} полей заполнено неверно",
+		}).
+		Add("uk", map[string]string{
+			"fields.invalid": "Поля заповнені невірно",
+		})
+
+	type args struct {
+		key     string
+		n       any
+		locales []string
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantText   string
+		wantLocale string
+		wantOk     bool
+	}{
+		{
+			name:       "Case 1",
+			args:       args{key: "fields.invalid", n: 22, locales: []string{"ru"}},
+			wantText:   "{count} поля заполнены неверно",
+			wantLocale: "ru",
+			wantOk:     true,
+		},
+		{
+			name:       "Case 2",
+			args:       args{key: "fields.invalid", n: 1, locales: []string{"de", "en"}},
+			wantText:   "{count} field is invalid",
+			wantLocale: "en",
+			wantOk:     true,
+		},
+		{
+			name:       "Case 3",
+			args:       args{key: "fields.invalid", n: 2.5, locales: []string{"ru"}},
+			wantText:   "{count} fields are invalid",
+			wantLocale: "en",
+			wantOk:     true,
+		},
+		{
+			name:       "Case 4",
+			args:       args{key: "fields.invalid", n: 5, locales: []string{"uk"}},
+			wantText:   "Поля заповнені невірно",
+			wantLocale: "uk",
+			wantOk:     true,
+		},
+		{
+			name:       "Case 5",
+			args:       args{key: "fields.unknown", n: 5, locales: []string{"ru"}},
+			wantText:   "",
+			wantLocale: "",
+			wantOk:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotText, gotLocale, gotOk := b.Plural(tt.args.key, tt.args.n, tt.args.locales...)
+
+			if gotText != tt.wantText {
+				t.Errorf("Plural() gotText = %v, want %v", gotText, tt.wantText)
+			}
+
+			if gotLocale != tt.wantLocale {
+				t.Errorf("Plural() gotLocale = %v, want %v", gotLocale, tt.wantLocale)
+			}
+
+			if gotOk != tt.wantOk {
+				t.Errorf("Plural() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBundle_MarshalJSON(t *testing.T) {
+	var data, err = json.Marshal(newTestBundle())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"default_locale":"en","fallbacks":{"kk":["ru","en"]},"translations":{"en":{"user.blocked":"User is blocked. ","user.not_found":"User not found. "},"kk":{},"ru":{"user.not_found":"Пользователь не найден. "}}}`
+
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+
+	var b = new(Bundle)
+
+	if err = json.Unmarshal(data, b); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(b, newTestBundle()) {
+		t.Errorf("UnmarshalJSON() = %v, want %v", b, newTestBundle())
+	}
+}
+
+func TestBundle_ZeroValue(t *testing.T) {
+	var b Bundle
+
+	if text, _, ok := b.Text("user.not_found", "ru"); ok || text != "" {
+		t.Errorf("Text() = %v, %v, want empty", text, ok)
+	}
+
+	if locale := b.Negotiate("ru"); locale != "" {
+		t.Errorf("Negotiate() = %v, want empty", locale)
+	}
+
+	if data, err := json.Marshal(&b); err != nil || string(data) != `{"default_locale":"","translations":{}}` {
+		t.Errorf("MarshalJSON() = %s, %v", data, err)
+	}
+
+	b.Add("ru", map[string]string{"user.not_found": "Пользователь не найден. "}).SetFallback("kk", "ru")
+
+	if text, locale, ok := b.Text("user.not_found", "kk"); !ok || locale != "ru" || text != "Пользователь не найден. " {
+		t.Errorf("Text() = %v, %v, %v", text, locale, ok)
+	}
+
+	if !reflect.DeepEqual(b.Locales(), []string{"ru"}) {
+		t.Errorf("Locales() = %v, want %v", b.Locales(), []string{"ru"})
+	}
+}
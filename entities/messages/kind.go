@@ -0,0 +1,225 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sm-errors/types"
+	"sync"
+)
+
+// Типы сообщений, зарегистрированные по умолчанию.
+const (
+	KindTemplate = "template"
+	KindPlural   = "plural"
+)
+
+// Поля формата JSON сообщения с типом.
+const (
+	kindField = "kind"
+	textField = "text"
+)
+
+// Ошибки реестра типов сообщений.
+var (
+	ErrKindEmpty     = errors.New("messages: empty message kind")
+	ErrKindDuplicate = errors.New("messages: duplicate message kind")
+)
+
+// kinds - реестр типов сообщений для распаковки.
+var kinds = struct {
+	factories map[string]func() types.KindMessage
+	rwMux     *sync.RWMutex
+}{
+	factories: map[string]func() types.KindMessage{
+		KindTemplate: func() types.KindMessage { return new(TemplateMessage) },
+		KindPlural:   func() types.KindMessage { return new(PluralMessage) },
+	},
+	rwMux: new(sync.RWMutex),
+}
+
+// RegisterKind - регистрация типа сообщения для распаковки из формата JSON.
+// Сообщение, создаваемое функцией, распаковывается через json.Unmarshal.
+func RegisterKind(kind string, fn func() types.KindMessage) (err error) {
+	if kind == "" {
+		return ErrKindEmpty
+	}
+
+	kinds.rwMux.Lock()
+	defer kinds.rwMux.Unlock()
+
+	if _, ok := kinds.factories[kind]; ok {
+		return fmt.Errorf("%w: '%s'", ErrKindDuplicate, kind)
+	}
+
+	kinds.factories[kind] = fn
+
+	return
+}
+
+// MustRegisterKind - регистрация типа сообщения с паникой в случае ошибки.
+func MustRegisterKind(kind string, fn func() types.KindMessage) {
+	if err := RegisterKind(kind, fn); err != nil {
+		panic(err)
+	}
+}
+
+// UnregisterKind - удаление типа сообщения из реестра.
+func UnregisterKind(kind string) {
+	kinds.rwMux.Lock()
+	defer kinds.rwMux.Unlock()
+
+	delete(kinds.factories, kind)
+}
+
+// kindFactory - получение функции создания сообщения по типу.
+func kindFactory(kind string) (fn func() types.KindMessage, ok bool) {
+	kinds.rwMux.RLock()
+	defer kinds.rwMux.RUnlock()
+
+	fn, ok = kinds.factories[kind]
+
+	return
+}
+
+// EncodeMessage - упаковать сообщение в формат JSON для передачи между сервисами.
+// Сообщения с типом упаковываются в объект с полями "kind" и "text" (итоговый текст),
+// остальные сообщения - в строку с текстом, если они не упаковываются сами.
+func EncodeMessage(m types.Message) (data []byte, err error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	var km, ok = m.(types.KindMessage)
+
+	if !ok {
+		if _, ok = m.(json.Marshaler); ok {
+			return json.Marshal(m)
+		}
+
+		return json.Marshal(m.String())
+	}
+
+	if data, err = json.Marshal(km); err != nil {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(data, &fields) != nil {
+		fields = make(map[string]json.RawMessage)
+	}
+
+	var prefix = new(bytes.Buffer)
+
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{name: kindField, value: km.Kind()},
+		{name: textField, value: km.String()},
+	} {
+		if _, ok = fields[f.name]; ok {
+			continue
+		}
+
+		var value, _ = json.Marshal(f.value)
+
+		prefix.WriteString(`"` + f.name + `":`)
+		prefix.Write(value)
+		prefix.WriteByte(',')
+	}
+
+	// Недостающие поля добавляются в начало объекта, поля сообщения сохраняют свой порядок.
+	if data = bytes.TrimSpace(data); len(data) < 2 || data[0] != '{' {
+		data = []byte("{}")
+	}
+
+	var body = bytes.TrimSpace(data[1 : len(data)-1])
+
+	if len(body) == 0 && prefix.Len() > 0 {
+		prefix.Truncate(prefix.Len() - 1)
+	}
+
+	data = append(append(append([]byte{'{'}, prefix.Bytes()...), body...), '}')
+
+	return
+}
+
+// DecodeMessage - распаковать сообщение из формата JSON.
+// Строка распаковывается в TextMessage, объект - в сообщение зарегистрированного типа
+// из поля "kind". Объекты неизвестного типа и объекты, которые не удалось распаковать,
+// заменяются текстовым сообщением с текстом из поля "text".
+func DecodeMessage(data []byte) (m types.Message, err error) {
+	data = bytes.TrimSpace(data)
+
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return
+	}
+
+	switch data[0] {
+	case '"':
+		{
+			var text string
+
+			if err = json.Unmarshal(data, &text); err != nil {
+				return
+			}
+
+			return new(TextMessage).Text(text), nil
+		}
+	case '{':
+		{
+			var header struct {
+				Kind string `json:"kind"`
+				Text string `json:"text"`
+			}
+
+			if err = json.Unmarshal(data, &header); err != nil {
+				return
+			}
+
+			if fn, ok := kindFactory(header.Kind); ok {
+				var km = fn()
+
+				if json.Unmarshal(data, km) == nil {
+					return km, nil
+				}
+			}
+
+			return new(TextMessage).Text(header.Text), nil
+		}
+	}
+
+	err = fmt.Errorf("messages: unexpected message %s", data)
+
+	return
+}
+
+// DecodeMessageXML - распаковать сообщение из элемента XML.
+// Элемент с вложенным элементом Template распаковывается в TemplateMessage,
+// остальные элементы - в TextMessage с текстом из вложенного элемента Text или текста элемента.
+func DecodeMessageXML(d *xml.Decoder, start xml.StartElement) (m types.Message, err error) {
+	var w = new(templateMessageXMLWrapper)
+
+	if err = d.DecodeElement(w, &start); err != nil {
+		return
+	}
+
+	switch {
+	case w.Template != nil:
+		{
+			var tm = new(TemplateMessage)
+
+			tm.fromXMLWrapper(w)
+
+			return tm, nil
+		}
+	case w.Text != nil:
+		return new(TextMessage).Text(*w.Text), nil
+	}
+
+	return new(TextMessage).Text(w.CharData), nil
+}
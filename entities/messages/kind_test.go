@@ -0,0 +1,270 @@
+package messages
+
+import (
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+// testKindMessage - сообщение с типом для тестов реестра.
+type testKindMessage struct {
+	Code  int    `json:"code"`
+	Label string `json:"label"`
+}
+
+// String - получение текста сообщения.
+func (m *testKindMessage) String() (str string) {
+	return m.Label
+}
+
+// Clone - копирование сообщения.
+func (m *testKindMessage) Clone() types.Message {
+	var m_ = *m
+	return &m_
+}
+
+// Kind - получение типа сообщения.
+func (m *testKindMessage) Kind() (kind string) {
+	return "test"
+}
+
+func TestRegisterKind(t *testing.T) {
+	t.Cleanup(func() {
+		UnregisterKind("test")
+	})
+
+	var fn = func() types.KindMessage { return new(testKindMessage) }
+
+	if err := RegisterKind("test", fn); err != nil {
+		t.Fatalf("RegisterKind() error = %v", err)
+	}
+
+	if err := RegisterKind("test", fn); !errors.Is(err, ErrKindDuplicate) {
+		t.Errorf("RegisterKind() error = %v, want %v", err, ErrKindDuplicate)
+	}
+
+	if err := RegisterKind("", fn); !errors.Is(err, ErrKindEmpty) {
+		t.Errorf("RegisterKind() error = %v, want %v", err, ErrKindEmpty)
+	}
+
+	var m = &testKindMessage{Code: 7, Label: "Custom"}
+
+	var data, err = EncodeMessage(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"kind":"test","text":"Custom","code":7,"label":"Custom"}`; string(data) != want {
+		t.Errorf("EncodeMessage() = %s, want %s", data, want)
+	}
+
+	var got types.Message
+
+	if got, err = DecodeMessage(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("DecodeMessage() = %v, want %v", got, m)
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        types.Message
+		wantData string
+	}{
+		{
+			name:     "Case 1",
+			m:        new(TextMessage).Text("Message. "),
+			wantData: `"Message. "`,
+		},
+		{
+			name:     "Case 2",
+			m:        nil,
+			wantData: `null`,
+		},
+		{
+			name: "Case 3",
+			m: new(TemplateMessage).
+				Key("U-000001").
+				Template("User {user_id} not found").
+				Arg("user_id", "u-1"),
+			wantData: `{"kind":"template","key":"U-000001","template":"User {user_id} not found","args":{"user_id":"u-1"},"text":"User u-1 not found"}`,
+		},
+		{
+			name: "Case 4",
+			m: new(PluralMessage).
+				Locale("en").
+				Form(PluralOne, "{count} item").
+				Form(PluralOther, "{count} items").
+				Count(2),
+			wantData: `{"kind":"plural","locale":"en","forms":{"one":"{count} item","other":"{count} items"},"args":{"count":2},"text":"2 items"}`,
+		},
+		{
+			name:     "Case 5",
+			m:        new(LocalizedMessage).Bundle(newTestBundle()).Key("user.not_found").Locale("ru"),
+			wantData: `"Пользователь не найден. "`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := EncodeMessage(tt.m)
+
+			if err != nil {
+				t.Fatalf("EncodeMessage() error = %v", err)
+			}
+
+			if string(gotData) != tt.wantData {
+				t.Errorf("EncodeMessage() = %s, want %s", gotData, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantM   types.Message
+		wantErr bool
+	}{
+		{
+			name:    "Case 1",
+			data:    `"Message. "`,
+			wantM:   new(TextMessage).Text("Message. "),
+			wantErr: false,
+		},
+		{
+			name:    "Case 2",
+			data:    `null`,
+			wantM:   nil,
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			data: `{"kind":"template","key":"U-000001","template":"User {user_id} not found","args":{"user_id":"u-1"},"text":"User u-1 not found"}`,
+			wantM: new(TemplateMessage).
+				Key("U-000001").
+				Template("User {user_id} not found").
+				Arg("user_id", "u-1"),
+			wantErr: false,
+		},
+		{
+			name: "Case 4",
+			data: `{"kind":"plural","locale":"en","forms":{"one":"{count} item","other":"{count} items"},"args":{"count":2},"text":"2 items"}`,
+			wantM: new(PluralMessage).
+				Locale("en").
+				Form(PluralOne, "{count} item").
+				Form(PluralOther, "{count} items").
+				Count(float64(2)),
+			wantErr: false,
+		},
+		{
+			name:    "Case 5",
+			data:    `{"kind":"unknown","payload":[1,2],"text":"Rendered text"}`,
+			wantM:   new(TextMessage).Text("Rendered text"),
+			wantErr: false,
+		},
+		{
+			name:    "Case 6",
+			data:    `{"kind":"template","template":42,"text":"Broken template"}`,
+			wantM:   new(TextMessage).Text("Broken template"),
+			wantErr: false,
+		},
+		{
+			name:    "Case 7",
+			data:    `42`,
+			wantM:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotM, err := DecodeMessage([]byte(tt.data))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotM, tt.wantM) {
+				t.Errorf("DecodeMessage() = %#v, want %#v", gotM, tt.wantM)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantM   types.Message
+		wantErr bool
+	}{
+		{
+			name:    "Case 1",
+			data:    `<Message>Message. </Message>`,
+			wantM:   new(TextMessage).Text("Message. "),
+			wantErr: false,
+		},
+		{
+			name:    "Case 2",
+			data:    `<Message></Message>`,
+			wantM:   new(TextMessage).Text(""),
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			data: `<Message><Key>user.not_found</Key><Template>User {user_id} not found</Template>` +
+				`<Args><Arg name="user_id">7</Arg></Args><Text>User 7 not found</Text></Message>`,
+			wantM:   new(TemplateMessage).Key("user.not_found").Template("User {user_id} not found").Arg("user_id", "7"),
+			wantErr: false,
+		},
+		{
+			name:    "Case 4",
+			data:    `<Message><Text>Plural text</Text></Message>`,
+			wantM:   new(TextMessage).Text("Plural text"),
+			wantErr: false,
+		},
+		{
+			name:    "Case 5",
+			data:    `<Message>Broken`,
+			wantM:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				d        = xml.NewDecoder(strings.NewReader(tt.data))
+				token, _ = d.Token()
+			)
+
+			gotM, err := DecodeMessageXML(d, token.(xml.StartElement))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeMessageXML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(gotM.String(), tt.wantM.String()) {
+				t.Errorf("DecodeMessageXML() = %q, want %q", gotM.String(), tt.wantM.String())
+			}
+
+			if reflect.TypeOf(gotM) != reflect.TypeOf(tt.wantM) {
+				t.Errorf("DecodeMessageXML() type = %T, want %T", gotM, tt.wantM)
+			}
+		})
+	}
+}
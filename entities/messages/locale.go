@@ -0,0 +1,96 @@
+package messages
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// localesContextKey - ключ предпочтительных языков в контексте.
+	localesContextKey struct{}
+
+	// acceptLanguage - язык из заголовка Accept-Language с его весом.
+	acceptLanguage struct {
+		locale string
+		q      float64
+	}
+)
+
+// WithLocales - добавить в контекст предпочтительные языки в порядке убывания приоритета.
+func WithLocales(ctx context.Context, locales ...string) context.Context {
+	var list = make([]string, 0, len(locales))
+
+	for _, locale := range locales {
+		if locale = NormalizeLocale(locale); locale != "" {
+			list = append(list, locale)
+		}
+	}
+
+	return context.WithValue(ctx, localesContextKey{}, list)
+}
+
+// WithAcceptLanguage - добавить в контекст предпочтительные языки из заголовка Accept-Language.
+func WithAcceptLanguage(ctx context.Context, header string) context.Context {
+	return WithLocales(ctx, ParseAcceptLanguage(header)...)
+}
+
+// LocalesFromContext - получение предпочтительных языков из контекста.
+func LocalesFromContext(ctx context.Context) (locales []string) {
+	if ctx == nil {
+		return
+	}
+
+	locales, _ = ctx.Value(localesContextKey{}).([]string)
+
+	return
+}
+
+// ParseAcceptLanguage - разбор заголовка Accept-Language в список языков по убыванию веса.
+// Языки с нулевым весом и "*" пропускаются.
+func ParseAcceptLanguage(header string) (locales []string) {
+	var list = make([]acceptLanguage, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		var (
+			fields = strings.Split(part, ";")
+			lang   = acceptLanguage{
+				locale: NormalizeLocale(fields[0]),
+				q:      1,
+			}
+		)
+
+		if lang.locale == "" || lang.locale == "*" {
+			continue
+		}
+
+		for _, param := range fields[1:] {
+			var name, value, ok = strings.Cut(strings.TrimSpace(param), "=")
+
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				lang.q = q
+			}
+		}
+
+		if lang.q <= 0 {
+			continue
+		}
+
+		list = append(list, lang)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].q > list[j].q
+	})
+
+	for _, lang := range list {
+		locales = append(locales, lang.locale)
+	}
+
+	return
+}
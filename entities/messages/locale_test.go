@@ -0,0 +1,76 @@
+package messages
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{
+			name:   "Case 1",
+			header: "kk-KZ,kk;q=0.9,ru;q=0.8,en;q=0.7,*;q=0.5",
+			want:   []string{"kk-kz", "kk", "ru", "en"},
+		},
+		{
+			name:   "Case 2",
+			header: "en;q=0.1, ru",
+			want:   []string{"ru", "en"},
+		},
+		{
+			name:   "Case 3",
+			header: "de;q=0, fr;q=abc",
+			want:   []string{"fr"},
+		},
+		{
+			name:   "Case 4",
+			header: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalesFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{
+			name: "Case 1",
+			ctx:  context.Background(),
+			want: nil,
+		},
+		{
+			name: "Case 2",
+			ctx:  WithLocales(context.Background(), "RU", "", "en_US"),
+			want: []string{"ru", "en-us"},
+		},
+		{
+			name: "Case 3",
+			ctx:  WithAcceptLanguage(context.Background(), "kk, ru;q=0.5"),
+			want: []string{"kk", "ru"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocalesFromContext(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocalesFromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package messages
+
+import (
+	"sm-errors/types"
+	"strings"
+)
+
+// LocalizedMessage - сообщение, текст которого выбирается из набора переводов по языку.
+// Если задан аргумент количества, перевод выбирается в форме множественного числа
+// (см. Bundle.Plural), в текст подставляются именованные аргументы.
+type LocalizedMessage struct {
+	bundle  *Bundle
+	key     string
+	locales []string
+	args    map[string]any
+}
+
+// String - получение текста сообщения для выбранных языков.
+// Если перевод не найден, возвращается ключ сообщения.
+func (m *LocalizedMessage) String() (str string) {
+	if m.bundle == nil {
+		return m.key
+	}
+
+	var ok bool
+
+	if n, plural := m.args[PluralCountArg]; plural {
+		str, _, ok = m.bundle.Plural(m.key, n, m.locales...)
+	} else {
+		str, _, ok = m.bundle.Text(m.key, m.locales...)
+	}
+
+	if !ok {
+		return m.key
+	}
+
+	return renderTemplate(str, m.args)
+}
+
+// Bundle - установить набор переводов сообщения.
+func (m *LocalizedMessage) Bundle(b *Bundle) *LocalizedMessage {
+	m.bundle = b
+	return m
+}
+
+// Key - установить ключ сообщения в наборе переводов.
+func (m *LocalizedMessage) Key(key string) *LocalizedMessage {
+	m.key = key
+	return m
+}
+
+// Locale - установить предпочтительные языки сообщения.
+func (m *LocalizedMessage) Locale(locales ...string) *LocalizedMessage {
+	m.SetLocales(locales...)
+	return m
+}
+
+// Count - установить значение количества для выбора формы множественного числа.
+func (m *LocalizedMessage) Count(n any) *LocalizedMessage {
+	m.SetArg(PluralCountArg, n)
+	return m
+}
+
+// Arg - установить значение аргумента сообщения.
+func (m *LocalizedMessage) Arg(name string, v any) *LocalizedMessage {
+	m.SetArg(name, v)
+	return m
+}
+
+// SetArg - установить значение аргумента сообщения.
+func (m *LocalizedMessage) SetArg(name string, v any) {
+	if m.args == nil {
+		m.args = make(map[string]any)
+	}
+
+	m.args[name] = v
+	return
+}
+
+// Args - получение копии аргументов сообщения.
+func (m *LocalizedMessage) Args() (args map[string]any) {
+	args = make(map[string]any, len(m.args))
+
+	for k, v := range m.args {
+		args[k] = v
+	}
+
+	return
+}
+
+// SetLocales - установить предпочтительные языки сообщения в порядке убывания приоритета.
+func (m *LocalizedMessage) SetLocales(locales ...string) {
+	m.locales = make([]string, 0, len(locales))
+
+	for _, locale := range locales {
+		m.locales = append(m.locales, NormalizeLocale(locale))
+	}
+
+	return
+}
+
+// Localize - получение текста сообщения для указанных языков без изменения сообщения.
+func (m *LocalizedMessage) Localize(locales ...string) (str string) {
+	var m_ = *m
+	m_.SetLocales(locales...)
+
+	return m_.String()
+}
+
+// Clone - копирование сообщения.
+func (m *LocalizedMessage) Clone() types.Message {
+	var m_ = &LocalizedMessage{
+		bundle: m.bundle,
+		key:    strings.Clone(m.key),
+	}
+
+	if m.locales != nil {
+		m_.locales = append(make([]string, 0, len(m.locales)), m.locales...)
+	}
+
+	if m.args != nil {
+		m_.args = m.Args()
+	}
+
+	return m_
+}
@@ -0,0 +1,126 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLocalizedMessage_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *LocalizedMessage
+		wantStr string
+	}{
+		{
+			name: "Case 1",
+			m: new(LocalizedMessage).
+				Bundle(newTestBundle()).
+				Key("user.not_found"),
+			wantStr: "User not found. ",
+		},
+		{
+			name: "Case 2",
+			m: new(LocalizedMessage).
+				Bundle(newTestBundle()).
+				Key("user.not_found").
+				Locale("kk", "en"),
+			wantStr: "Пользователь не найден. ",
+		},
+		{
+			name: "Case 3",
+			m: new(LocalizedMessage).
+				Bundle(newTestBundle()).
+				Key("user.unknown").
+				Locale("ru"),
+			wantStr: "user.unknown",
+		},
+		{
+			name: "Case 4",
+			m: new(LocalizedMessage).
+				Key("user.not_found"),
+			wantStr: "user.not_found",
+		},
+		{
+			name: "Case 5",
+			m: new(LocalizedMessage).
+				Bundle(newTestBundle().Add("ru", map[string]string{
+					"user.blocked#few":  "{count} пользователя заблокированы {by}",
+					"user.blocked#many": "{count} пользователей заблокированы {by}",
+				})).
+				Key("user.blocked").
+				Locale("ru").
+				Arg("by", "администратором").
+				Count(5),
+			wantStr: "5 пользователей заблокированы администратором",
+		},
+		{
+			name: "Case 6",
+			m: new(LocalizedMessage).
+				Bundle(newTestBundle()).
+				Key("user.blocked").
+				Locale("ru").
+				Count(2),
+			wantStr: "User is blocked. ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.m.String(); gotStr != tt.wantStr {
+				t.Errorf("String() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestLocalizedMessage_Localize(t *testing.T) {
+	var m = new(LocalizedMessage).
+		Bundle(newTestBundle()).
+		Key("user.not_found").
+		Locale("en")
+
+	if got := m.Localize("ru"); got != "Пользователь не найден. " {
+		t.Errorf("Localize() = %v, want %v", got, "Пользователь не найден. ")
+	}
+
+	if got := m.String(); got != "User not found. " {
+		t.Errorf("Localize() changed message locale, String() = %v", got)
+	}
+}
+
+func TestLocalizedMessage_Clone(t *testing.T) {
+	var (
+		b = newTestBundle()
+		m = new(LocalizedMessage).
+			Bundle(b).
+			Key("user.not_found").
+			Locale("ru")
+	)
+
+	var got = m.Clone().(*LocalizedMessage)
+
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("Clone() = %v, want %v", got, m)
+	}
+
+	got.SetLocales("en")
+
+	if m.String() != "Пользователь не найден. " {
+		t.Errorf("Clone() shares locales with source")
+	}
+}
+
+func TestLocalizedMessage_MarshalJSON(t *testing.T) {
+	var w = map[string]any{
+		"message": new(LocalizedMessage).
+			Bundle(newTestBundle()).
+			Key("user.not_found").
+			Locale("ru").
+			String(),
+	}
+
+	if data, _ := json.Marshal(w); string(data) != `{"message":"Пользователь не найден. "}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+}
@@ -0,0 +1,282 @@
+package messages
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Категории множественного числа по CLDR.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// PluralCountArg - имя аргумента сообщения, по которому выбирается форма множественного числа.
+const PluralCountArg = "count"
+
+type (
+	// PluralCategory - категория множественного числа по CLDR.
+	PluralCategory string
+
+	// PluralOperands - операнды числа для правил множественного числа по CLDR:
+	// N - абсолютное значение, I - целая часть, V - количество видимых дробных цифр,
+	// F - видимые дробные цифры, T - видимые дробные цифры без конечных нулей.
+	PluralOperands struct {
+		N float64
+		I int64
+		V int
+		F int64
+		T int64
+	}
+
+	// PluralRule - правило выбора категории множественного числа.
+	PluralRule func(op PluralOperands) (c PluralCategory)
+)
+
+// pluralRules - правила множественного числа по языкам.
+var pluralRules = struct {
+	rules map[string]PluralRule
+	rwMux *sync.RWMutex
+}{
+	rules: map[string]PluralRule{
+		"ru": pluralRuleEastSlavic,
+		"uk": pluralRuleEastSlavic,
+		"en": pluralRuleOneInteger,
+		"de": pluralRuleOneInteger,
+		"kk": pluralRuleOne,
+	},
+	rwMux: new(sync.RWMutex),
+}
+
+// gettextPluralForms - формы множественного числа gettext языка: порядок категорий
+// в msgstr[n], значение заголовка Plural-Forms и категория формы для PluralOther,
+// если ее нет среди форм gettext.
+type gettextPluralForms struct {
+	categories []PluralCategory
+	header     string
+	other      PluralCategory
+}
+
+// pluralForms - формы множественного числа gettext по языкам.
+var pluralForms = map[string]gettextPluralForms{
+	"ru": {
+		categories: []PluralCategory{PluralOne, PluralFew, PluralMany},
+		header:     "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		other:      PluralFew,
+	},
+	"uk": {
+		categories: []PluralCategory{PluralOne, PluralFew, PluralMany},
+		header:     "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		other:      PluralFew,
+	},
+	"en": {
+		categories: []PluralCategory{PluralOne, PluralOther},
+		header:     "nplurals=2; plural=(n != 1);",
+	},
+	"de": {
+		categories: []PluralCategory{PluralOne, PluralOther},
+		header:     "nplurals=2; plural=(n != 1);",
+	},
+	"kk": {
+		categories: []PluralCategory{PluralOne, PluralOther},
+		header:     "nplurals=2; plural=(n != 1);",
+	},
+}
+
+// PluralForms - получение порядка категорий множественного числа в формах gettext
+// и значения заголовка Plural-Forms для языка. Для неизвестных языков используются
+// формы английского языка.
+func PluralForms(locale string) (categories []PluralCategory, header string) {
+	var forms = pluralFormsOf(locale)
+
+	return append([]PluralCategory(nil), forms.categories...), forms.header
+}
+
+// PluralOtherForm - получение категории формы gettext, перевод которой используется для категории PluralOther.
+// В gettext у русского и украинского языков нет формы для дробных чисел, поэтому для них
+// берется форма PluralFew: "1,5 поля", как "2 поля". Для остальных языков возвращается PluralOther.
+func PluralOtherForm(locale string) (c PluralCategory) {
+	if c = pluralFormsOf(locale).other; c == "" {
+		c = PluralOther
+	}
+
+	return
+}
+
+// pluralFormsOf - получение форм множественного числа gettext для языка, его базового языка или английского языка.
+func pluralFormsOf(locale string) (forms gettextPluralForms) {
+	var ok bool
+
+	if forms, ok = pluralForms[NormalizeLocale(locale)]; !ok {
+		if forms, ok = pluralForms[BaseLocale(locale)]; !ok {
+			forms = pluralForms["en"]
+		}
+	}
+
+	return
+}
+
+// RegisterPluralRule - регистрация правила множественного числа для языка.
+// Существующее правило языка заменяется.
+func RegisterPluralRule(locale string, rule PluralRule) {
+	pluralRules.rwMux.Lock()
+	defer pluralRules.rwMux.Unlock()
+
+	pluralRules.rules[NormalizeLocale(locale)] = rule
+}
+
+// PluralCategoryOf - получение категории множественного числа для числа и языка.
+// Правило ищется для языка, затем для его базового языка. Если правило не найдено
+// или значение не является числом, возвращается категория PluralOther.
+func PluralCategoryOf(locale string, n any) (c PluralCategory) {
+	var op, ok = ParsePluralOperands(n)
+
+	if !ok {
+		return PluralOther
+	}
+
+	pluralRules.rwMux.RLock()
+
+	var rule, found = pluralRules.rules[NormalizeLocale(locale)]
+
+	if !found {
+		rule, found = pluralRules.rules[BaseLocale(locale)]
+	}
+
+	pluralRules.rwMux.RUnlock()
+
+	if !found {
+		return PluralOther
+	}
+
+	return rule(op)
+}
+
+// ParsePluralOperands - получение операндов множественного числа из значения.
+// Поддерживаются целые и дробные числа, json.Number и строки с десятичной записью числа,
+// в строках учитываются конечные нули дробной части, например "1.50".
+func ParsePluralOperands(n any) (op PluralOperands, ok bool) {
+	switch v := n.(type) {
+	case int:
+		return integerOperands(int64(v)), true
+	case int8:
+		return integerOperands(int64(v)), true
+	case int16:
+		return integerOperands(int64(v)), true
+	case int32:
+		return integerOperands(int64(v)), true
+	case int64:
+		return integerOperands(v), true
+	case uint:
+		return parseDecimalOperands(strconv.FormatUint(uint64(v), 10))
+	case uint8:
+		return integerOperands(int64(v)), true
+	case uint16:
+		return integerOperands(int64(v)), true
+	case uint32:
+		return integerOperands(int64(v)), true
+	case uint64:
+		return parseDecimalOperands(strconv.FormatUint(v, 10))
+	case float32:
+		return parseDecimalOperands(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return parseDecimalOperands(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		return parseDecimalOperands(string(v))
+	case string:
+		return parseDecimalOperands(v)
+	}
+
+	return
+}
+
+// integerOperands - получение операндов целого числа.
+func integerOperands(n int64) (op PluralOperands) {
+	if n < 0 {
+		n = -n
+	}
+
+	return PluralOperands{
+		N: float64(n),
+		I: n,
+	}
+}
+
+// parseDecimalOperands - получение операндов из десятичной записи числа.
+func parseDecimalOperands(str string) (op PluralOperands, ok bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "-")
+
+	var n, err = strconv.ParseFloat(str, 64)
+
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) || strings.ContainsAny(str, "eE") {
+		return
+	}
+
+	var intPart, fracPart, _ = strings.Cut(str, ".")
+
+	op.N = n
+
+	if op.I, err = strconv.ParseInt(intPart, 10, 64); err != nil {
+		op.I = int64(n)
+	}
+
+	if fracPart != "" {
+		op.V = len(fracPart)
+		op.F, _ = strconv.ParseInt(fracPart, 10, 64)
+
+		if t := strings.TrimRight(fracPart, "0"); t != "" {
+			op.T, _ = strconv.ParseInt(t, 10, 64)
+		}
+	}
+
+	ok = true
+
+	return
+}
+
+// pluralRuleEastSlavic - правило для русского и украинского языков.
+func pluralRuleEastSlavic(op PluralOperands) (c PluralCategory) {
+	if op.V != 0 {
+		return PluralOther
+	}
+
+	var (
+		mod10  = op.I % 10
+		mod100 = op.I % 100
+	)
+
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRuleOneInteger - правило для английского и немецкого языков:
+// единственное число только для целой единицы без дробной части.
+func pluralRuleOneInteger(op PluralOperands) (c PluralCategory) {
+	if op.I == 1 && op.V == 0 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// pluralRuleOne - правило для казахского языка: единственное число для n = 1.
+func pluralRuleOne(op PluralOperands) (c PluralCategory) {
+	if op.N == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
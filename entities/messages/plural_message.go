@@ -0,0 +1,175 @@
+package messages
+
+import (
+	"encoding/json"
+	"sm-errors/types"
+	"strings"
+)
+
+type (
+	// PluralMessage - сообщение с формами множественного числа одного языка.
+	// Форма выбирается по правилам языка и значению аргумента количества,
+	// в выбранную форму подставляются именованные аргументы, например "{count} поля заполнены неверно".
+	PluralMessage struct {
+		locale   string
+		forms    map[PluralCategory]string
+		countArg string
+		args     map[string]any
+	}
+
+	// pluralMessageWrapper - структура обертка для упаковки сообщения с формами множественного числа.
+	pluralMessageWrapper struct {
+		Locale   string                    `json:"locale,omitempty"`
+		Forms    map[PluralCategory]string `json:"forms"`
+		CountArg string                    `json:"count_arg,omitempty"`
+		Args     map[string]any            `json:"args,omitempty"`
+		Text     string                    `json:"text"`
+	}
+)
+
+// String - получение текста сообщения в форме, соответствующей количеству.
+// Если форма категории не задана, используется форма PluralOther.
+func (m *PluralMessage) String() (str string) {
+	var (
+		category = PluralCategoryOf(m.locale, m.args[m.CountArg()])
+		ok       bool
+	)
+
+	if str, ok = m.forms[category]; !ok {
+		str = m.forms[PluralOther]
+	}
+
+	return renderTemplate(str, m.args)
+}
+
+// Kind - получение типа сообщения для передачи между сервисами.
+func (m *PluralMessage) Kind() (kind string) {
+	return KindPlural
+}
+
+// Locale - установить язык, правила которого используются для выбора формы.
+func (m *PluralMessage) Locale(locale string) *PluralMessage {
+	m.locale = NormalizeLocale(locale)
+	return m
+}
+
+// Form - установить текст формы для категории множественного числа.
+func (m *PluralMessage) Form(category PluralCategory, text string) *PluralMessage {
+	if m.forms == nil {
+		m.forms = make(map[PluralCategory]string)
+	}
+
+	m.forms[category] = text
+	return m
+}
+
+// Forms - установить тексты форм множественного числа.
+func (m *PluralMessage) Forms(forms map[PluralCategory]string) *PluralMessage {
+	for category, text := range forms {
+		m.Form(category, text)
+	}
+
+	return m
+}
+
+// CountBy - установить имя аргумента количества, по умолчанию PluralCountArg.
+func (m *PluralMessage) CountBy(name string) *PluralMessage {
+	m.countArg = name
+	return m
+}
+
+// Count - установить значение количества.
+func (m *PluralMessage) Count(n any) *PluralMessage {
+	m.SetArg(m.CountArg(), n)
+	return m
+}
+
+// Arg - установить значение аргумента сообщения.
+func (m *PluralMessage) Arg(name string, v any) *PluralMessage {
+	m.SetArg(name, v)
+	return m
+}
+
+// CountArg - получение имени аргумента количества.
+func (m *PluralMessage) CountArg() (name string) {
+	if m.countArg == "" {
+		return PluralCountArg
+	}
+
+	return m.countArg
+}
+
+// SetArg - установить значение аргумента сообщения.
+func (m *PluralMessage) SetArg(name string, v any) {
+	if m.args == nil {
+		m.args = make(map[string]any)
+	}
+
+	m.args[name] = v
+	return
+}
+
+// Args - получение копии аргументов сообщения.
+func (m *PluralMessage) Args() (args map[string]any) {
+	args = make(map[string]any, len(m.args))
+
+	for k, v := range m.args {
+		args[k] = v
+	}
+
+	return
+}
+
+// Clone - копирование сообщения.
+func (m *PluralMessage) Clone() types.Message {
+	var m_ = &PluralMessage{
+		locale:   strings.Clone(m.locale),
+		countArg: strings.Clone(m.countArg),
+	}
+
+	if m.forms != nil {
+		m_.forms = make(map[PluralCategory]string, len(m.forms))
+
+		for category, text := range m.forms {
+			m_.forms[category] = text
+		}
+	}
+
+	if m.args != nil {
+		m_.args = m.Args()
+	}
+
+	return m_
+}
+
+// MarshalJSON - упаковать в формат JSON.
+func (m *PluralMessage) MarshalJSON() ([]byte, error) {
+	var w = &pluralMessageWrapper{
+		Locale:   m.locale,
+		Forms:    m.forms,
+		CountArg: m.countArg,
+		Text:     m.String(),
+	}
+
+	if len(m.args) > 0 {
+		w.Args = m.args
+	}
+
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON - распаковать из формата JSON.
+func (m *PluralMessage) UnmarshalJSON(data []byte) (err error) {
+	var w = new(pluralMessageWrapper)
+
+	if err = json.Unmarshal(data, w); err != nil {
+		return
+	}
+
+	m.locale = NormalizeLocale(w.Locale)
+	m.forms = w.Forms
+	m.countArg = w.CountArg
+	m.args = w.Args
+
+	return
+}
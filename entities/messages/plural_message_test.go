@@ -0,0 +1,91 @@
+package messages
+
+import (
+	"testing"
+)
+
+func TestPluralMessage_String(t *testing.T) {
+	var newMessage = func() *PluralMessage {
+		return new(PluralMessage).
+			Locale("ru").
+			Form(PluralOne, "{count} поле заполнено неверно").
+			Form(PluralFew, "{count} поля заполнены неверно").
+			Form(PluralMany, "{count} полей заполнено неверно").
+			Form(PluralOther, "{count} поля заполнено неверно")
+	}
+
+	tests := []struct {
+		name    string
+		m       *PluralMessage
+		wantStr string
+	}{
+		{
+			name:    "Case 1",
+			m:       newMessage().Count(1),
+			wantStr: "1 поле заполнено неверно",
+		},
+		{
+			name:    "Case 2",
+			m:       newMessage().Count(3),
+			wantStr: "3 поля заполнены неверно",
+		},
+		{
+			name:    "Case 3",
+			m:       newMessage().Count(11),
+			wantStr: "11 полей заполнено неверно",
+		},
+		{
+			name:    "Case 4",
+			m:       newMessage().Count(1.5),
+			wantStr: "1.5 поля заполнено неверно",
+		},
+		{
+			name: "Case 5",
+			m: new(PluralMessage).
+				Locale("en").
+				CountBy("n").
+				Form(PluralOne, "{n} item in {list}").
+				Form(PluralOther, "{n} items in {list}").
+				Arg("list", "cart").
+				Count(2),
+			wantStr: "2 items in cart",
+		},
+		{
+			name: "Case 6",
+			m: new(PluralMessage).
+				Locale("en").
+				Form(PluralOther, "{count} items"),
+			wantStr: "{count} items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.m.String(); gotStr != tt.wantStr {
+				t.Errorf("String() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestPluralMessage_Clone(t *testing.T) {
+	var (
+		m = new(PluralMessage).
+			Locale("en").
+			Form(PluralOne, "{count} item").
+			Form(PluralOther, "{count} items").
+			Count(1)
+		c = m.Clone().(*PluralMessage)
+	)
+
+	c.SetArg(PluralCountArg, 5)
+	c.Form(PluralOne, "one item")
+
+	if got := m.String(); got != "1 item" {
+		t.Errorf("String() = %v, want %v", got, "1 item")
+	}
+
+	if got := c.String(); got != "5 items" {
+		t.Errorf("String() = %v, want %v", got, "5 items")
+	}
+}
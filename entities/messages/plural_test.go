@@ -0,0 +1,145 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPluralCategoryOf(t *testing.T) {
+	type args struct {
+		locale string
+		n      any
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wantC PluralCategory
+	}{
+		{name: "Case 1", args: args{locale: "ru", n: 1}, wantC: PluralOne},
+		{name: "Case 2", args: args{locale: "ru", n: 21}, wantC: PluralOne},
+		{name: "Case 3", args: args{locale: "ru", n: 11}, wantC: PluralMany},
+		{name: "Case 4", args: args{locale: "ru", n: 3}, wantC: PluralFew},
+		{name: "Case 5", args: args{locale: "ru", n: 14}, wantC: PluralMany},
+		{name: "Case 6", args: args{locale: "ru", n: 22}, wantC: PluralFew},
+		{name: "Case 7", args: args{locale: "ru", n: 0}, wantC: PluralMany},
+		{name: "Case 8", args: args{locale: "ru", n: 1.5}, wantC: PluralOther},
+		{name: "Case 9", args: args{locale: "ru-RU", n: int64(101)}, wantC: PluralOne},
+		{name: "Case 10", args: args{locale: "uk", n: 112}, wantC: PluralMany},
+		{name: "Case 11", args: args{locale: "uk", n: uint8(4)}, wantC: PluralFew},
+		{name: "Case 12", args: args{locale: "en", n: 1}, wantC: PluralOne},
+		{name: "Case 13", args: args{locale: "en", n: "1.0"}, wantC: PluralOther},
+		{name: "Case 14", args: args{locale: "en", n: 2}, wantC: PluralOther},
+		{name: "Case 15", args: args{locale: "de", n: -1}, wantC: PluralOne},
+		{name: "Case 16", args: args{locale: "kk", n: 1}, wantC: PluralOne},
+		{name: "Case 17", args: args{locale: "kk", n: "1.0"}, wantC: PluralOne},
+		{name: "Case 18", args: args{locale: "kk", n: 5}, wantC: PluralOther},
+		{name: "Case 19", args: args{locale: "fr", n: 1}, wantC: PluralOther},
+		{name: "Case 20", args: args{locale: "ru", n: "many"}, wantC: PluralOther},
+		{name: "Case 21", args: args{locale: "ru", n: json.Number("5")}, wantC: PluralMany},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotC := PluralCategoryOf(tt.args.locale, tt.args.n); gotC != tt.wantC {
+				t.Errorf("PluralCategoryOf() = %v, want %v", gotC, tt.wantC)
+			}
+		})
+	}
+}
+
+func TestPluralOtherForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		wantC  PluralCategory
+	}{
+		{name: "Case 1", locale: "ru", wantC: PluralFew},
+		{name: "Case 2", locale: "uk-UA", wantC: PluralFew},
+		{name: "Case 3", locale: "en", wantC: PluralOther},
+		{name: "Case 4", locale: "fr", wantC: PluralOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotC := PluralOtherForm(tt.locale); gotC != tt.wantC {
+				t.Errorf("PluralOtherForm() = %v, want %v", gotC, tt.wantC)
+			}
+		})
+	}
+}
+
+func TestParsePluralOperands(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      any
+		wantOp PluralOperands
+		wantOk bool
+	}{
+		{
+			name:   "Case 1",
+			n:      -15,
+			wantOp: PluralOperands{N: 15, I: 15},
+			wantOk: true,
+		},
+		{
+			name:   "Case 2",
+			n:      "1.50",
+			wantOp: PluralOperands{N: 1.5, I: 1, V: 2, F: 50, T: 5},
+			wantOk: true,
+		},
+		{
+			name:   "Case 3",
+			n:      2.25,
+			wantOp: PluralOperands{N: 2.25, I: 2, V: 2, F: 25, T: 25},
+			wantOk: true,
+		},
+		{
+			name:   "Case 4",
+			n:      "1e3",
+			wantOp: PluralOperands{},
+			wantOk: false,
+		},
+		{
+			name:   "Case 5",
+			n:      true,
+			wantOp: PluralOperands{},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOp, gotOk := ParsePluralOperands(tt.n)
+
+			if !reflect.DeepEqual(gotOp, tt.wantOp) {
+				t.Errorf("ParsePluralOperands() gotOp = %v, want %v", gotOp, tt.wantOp)
+			}
+
+			if gotOk != tt.wantOk {
+				t.Errorf("ParsePluralOperands() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRegisterPluralRule(t *testing.T) {
+	t.Cleanup(func() {
+		pluralRules.rwMux.Lock()
+		delete(pluralRules.rules, "xx")
+		pluralRules.rwMux.Unlock()
+	})
+
+	RegisterPluralRule("xx", func(op PluralOperands) PluralCategory {
+		if op.I == 2 {
+			return PluralTwo
+		}
+
+		return PluralOther
+	})
+
+	if got := PluralCategoryOf("xx-YY", 2); got != PluralTwo {
+		t.Errorf("PluralCategoryOf() = %v, want %v", got, PluralTwo)
+	}
+}
@@ -0,0 +1,479 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Заголовки файла переводов gettext.
+const (
+	POHeaderLanguage    = "Language"
+	POHeaderPluralForms = "Plural-Forms"
+	POHeaderContentType = "Content-Type"
+)
+
+// POFlagFuzzy - флаг неточного перевода, такие переводы не используются.
+const POFlagFuzzy = "fuzzy"
+
+type (
+	// POFile - файл переводов в формате gettext (.po или шаблон .pot).
+	POFile struct {
+		Name    string
+		Header  []POHeaderField
+		Entries []*POEntry
+	}
+
+	// POHeaderField - поле заголовка файла переводов.
+	POHeaderField struct {
+		Name  string
+		Value string
+	}
+
+	// POEntry - запись файла переводов.
+	// Для записей с множественным числом Str содержит формы в порядке Plural-Forms.
+	POEntry struct {
+		Comments          []string
+		ExtractedComments []string
+		References        []string
+		Flags             []string
+
+		Context  string
+		ID       string
+		IDPlural string
+		Str      []string
+
+		Obsolete bool
+	}
+
+	// POParseError - ошибка разбора файла переводов.
+	POParseError struct {
+		File   string
+		Line   int
+		Reason string
+	}
+)
+
+// Error - получение текста ошибки разбора.
+func (e *POParseError) Error() (s string) {
+	return fmt.Sprintf("po: %s:%d: %s", e.File, e.Line, e.Reason)
+}
+
+// ParsePO - разбор файла переводов.
+func ParsePO(name string, r io.Reader) (f *POFile, err error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		entry   *POEntry
+		target  *string
+		line    int
+	)
+
+	f = &POFile{
+		Name: name,
+	}
+
+	var fail = func(format string, a ...any) error {
+		return &POParseError{
+			File:   name,
+			Line:   line,
+			Reason: fmt.Sprintf(format, a...),
+		}
+	}
+
+	var flush = func() {
+		if entry == nil {
+			return
+		}
+
+		if entry.ID == "" && entry.Context == "" && !entry.Obsolete {
+			f.parseHeader(strings.Join(entry.Str, ""))
+		} else {
+			f.Entries = append(f.Entries, entry)
+		}
+
+		entry, target = nil, nil
+	}
+
+	var current = func() *POEntry {
+		if entry == nil {
+			entry = new(POEntry)
+		}
+
+		return entry
+	}
+
+	for scanner.Scan() {
+		line++
+
+		var text = strings.TrimSpace(scanner.Text())
+
+		if text == "" {
+			flush()
+			continue
+		}
+
+		var obsolete bool
+
+		if strings.HasPrefix(text, "#~") {
+			obsolete = true
+			text = strings.TrimSpace(text[2:])
+		}
+
+		// Комментарии после msgstr относятся к следующей записи.
+		if strings.HasPrefix(text, "#") && entry != nil && len(entry.Str) > 0 {
+			flush()
+		}
+
+		switch {
+		case strings.HasPrefix(text, "#."):
+			{
+				current().ExtractedComments = append(current().ExtractedComments, strings.TrimSpace(text[2:]))
+			}
+		case strings.HasPrefix(text, "#:"):
+			{
+				current().References = append(current().References, strings.Fields(text[2:])...)
+			}
+		case strings.HasPrefix(text, "#,"):
+			{
+				for _, flag := range strings.Split(text[2:], ",") {
+					if flag = strings.TrimSpace(flag); flag != "" {
+						current().Flags = append(current().Flags, flag)
+					}
+				}
+			}
+		case strings.HasPrefix(text, "#|"):
+			{
+				// Предыдущие значения записи не используются.
+			}
+		case strings.HasPrefix(text, "#"):
+			{
+				current().Comments = append(current().Comments, strings.TrimSpace(text[1:]))
+			}
+		case strings.HasPrefix(text, `"`):
+			{
+				if target == nil {
+					return nil, fail("unexpected string")
+				}
+
+				var s, uErr = unquotePO(text)
+
+				if uErr != nil {
+					return nil, fail("invalid string: %v", uErr)
+				}
+
+				*target += s
+			}
+		default:
+			{
+				var keyword, value, _ = strings.Cut(text, " ")
+
+				// Новая запись начинается с msgctxt или msgid после msgstr.
+				if entry != nil && len(entry.Str) > 0 && (keyword == "msgctxt" || keyword == "msgid") {
+					flush()
+				}
+
+				var s, uErr = unquotePO(strings.TrimSpace(value))
+
+				if uErr != nil {
+					return nil, fail("invalid string: %v", uErr)
+				}
+
+				var e = current()
+				e.Obsolete = e.Obsolete || obsolete
+
+				switch {
+				case keyword == "msgctxt":
+					{
+						e.Context = s
+						target = &e.Context
+					}
+				case keyword == "msgid":
+					{
+						e.ID = s
+						target = &e.ID
+					}
+				case keyword == "msgid_plural":
+					{
+						e.IDPlural = s
+						target = &e.IDPlural
+					}
+				case keyword == "msgstr":
+					{
+						e.Str = append(e.Str, s)
+						target = &e.Str[len(e.Str)-1]
+					}
+				case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+					{
+						var n, nErr = strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+
+						if nErr != nil || n != len(e.Str) {
+							return nil, fail("unexpected plural form index '%s'", keyword)
+						}
+
+						e.Str = append(e.Str, s)
+						target = &e.Str[len(e.Str)-1]
+					}
+				default:
+					{
+						return nil, fail("unknown keyword '%s'", keyword)
+					}
+				}
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return
+}
+
+// HeaderValue - получение значения поля заголовка.
+func (f *POFile) HeaderValue(name string) (value string) {
+	for _, h := range f.Header {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+
+	return
+}
+
+// SetHeader - установить значение поля заголовка.
+func (f *POFile) SetHeader(name, value string) *POFile {
+	for i, h := range f.Header {
+		if strings.EqualFold(h.Name, name) {
+			f.Header[i].Value = value
+			return f
+		}
+	}
+
+	f.Header = append(f.Header, POHeaderField{
+		Name:  name,
+		Value: value,
+	})
+
+	return f
+}
+
+// Language - получение языка файла переводов из заголовка.
+func (f *POFile) Language() (locale string) {
+	return NormalizeLocale(f.HeaderValue(POHeaderLanguage))
+}
+
+// Lookup - поиск записи по контексту и исходному тексту.
+func (f *POFile) Lookup(context, id string) (e *POEntry, ok bool) {
+	for _, e = range f.Entries {
+		if !e.Obsolete && e.Context == context && e.ID == id {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
+
+// Bytes - упаковать файл переводов.
+func (f *POFile) Bytes() (data []byte) {
+	var buf = new(bytes.Buffer)
+
+	// Заголовок
+	{
+		var header = new(strings.Builder)
+
+		for _, h := range f.Header {
+			header.WriteString(h.Name + ": " + h.Value + "\n")
+		}
+
+		buf.WriteString("msgid \"\"\n")
+		writePOString(buf, "", "msgstr", header.String())
+	}
+
+	for _, e := range f.Entries {
+		buf.WriteString("\n")
+		e.write(buf)
+	}
+
+	return buf.Bytes()
+}
+
+// WriteTo - запись файла переводов.
+func (f *POFile) WriteTo(w io.Writer) (n int64, err error) {
+	var c int
+
+	c, err = w.Write(f.Bytes())
+	n = int64(c)
+
+	return
+}
+
+// parseHeader - разбор заголовка файла переводов.
+func (f *POFile) parseHeader(str string) {
+	for _, line := range strings.Split(str, "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			f.SetHeader(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+}
+
+// Fuzzy - проверка наличия флага неточного перевода.
+func (e *POEntry) Fuzzy() (ok bool) {
+	for _, flag := range e.Flags {
+		if flag == POFlagFuzzy {
+			return true
+		}
+	}
+
+	return
+}
+
+// Translated - проверка наличия точного перевода во всех формах.
+func (e *POEntry) Translated() (ok bool) {
+	if len(e.Str) == 0 || e.Fuzzy() {
+		return
+	}
+
+	for _, s := range e.Str {
+		if s == "" {
+			return
+		}
+	}
+
+	return true
+}
+
+// write - упаковать запись файла переводов.
+func (e *POEntry) write(buf *bytes.Buffer) {
+	var prefix string
+
+	if e.Obsolete {
+		prefix = "#~ "
+	}
+
+	for _, c := range e.Comments {
+		buf.WriteString(strings.TrimRight("# "+c, " ") + "\n")
+	}
+
+	for _, c := range e.ExtractedComments {
+		buf.WriteString("#. " + c + "\n")
+	}
+
+	if len(e.References) > 0 {
+		buf.WriteString("#: " + strings.Join(e.References, " ") + "\n")
+	}
+
+	if len(e.Flags) > 0 {
+		buf.WriteString("#, " + strings.Join(e.Flags, ", ") + "\n")
+	}
+
+	if e.Context != "" {
+		writePOString(buf, prefix, "msgctxt", e.Context)
+	}
+
+	writePOString(buf, prefix, "msgid", e.ID)
+
+	if e.IDPlural == "" {
+		var str string
+
+		if len(e.Str) > 0 {
+			str = e.Str[0]
+		}
+
+		writePOString(buf, prefix, "msgstr", str)
+		return
+	}
+
+	writePOString(buf, prefix, "msgid_plural", e.IDPlural)
+
+	var forms = e.Str
+
+	if len(forms) == 0 {
+		forms = []string{"", ""}
+	}
+
+	for i, str := range forms {
+		writePOString(buf, prefix, fmt.Sprintf("msgstr[%d]", i), str)
+	}
+}
+
+// writePOString - запись строки файла переводов, многострочные значения
+// разбиваются по переводам строк. Префикс записывается перед каждой строкой.
+func writePOString(buf *bytes.Buffer, prefix, keyword, str string) {
+	var lines = strings.SplitAfter(str, "\n")
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) <= 1 {
+		buf.WriteString(prefix + keyword + " " + quotePO(str) + "\n")
+		return
+	}
+
+	buf.WriteString(prefix + keyword + " \"\"\n")
+
+	for _, line := range lines {
+		buf.WriteString(prefix + quotePO(line) + "\n")
+	}
+}
+
+// poEscaper - экранирование строк файла переводов.
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// quotePO - упаковать строку файла переводов в кавычки.
+func quotePO(str string) (quoted string) {
+	return `"` + poEscaper.Replace(str) + `"`
+}
+
+// unquotePO - распаковать строку файла переводов из кавычек.
+func unquotePO(quoted string) (str string, err error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		err = fmt.Errorf("string %s is not quoted", quoted)
+		return
+	}
+
+	var (
+		buf     = new(strings.Builder)
+		escaped bool
+	)
+
+	for _, r := range quoted[1 : len(quoted)-1] {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				buf.WriteRune(r)
+			}
+
+			continue
+		}
+
+		escaped = false
+
+		switch r {
+		case 'n':
+			buf.WriteByte('\n')
+		case 't':
+			buf.WriteByte('\t')
+		case 'r':
+			buf.WriteByte('\r')
+		case '"', '\\':
+			buf.WriteRune(r)
+		default:
+			err = fmt.Errorf("unknown escape sequence '\\%c'", r)
+			return
+		}
+	}
+
+	if escaped {
+		err = fmt.Errorf("unterminated escape sequence")
+		return
+	}
+
+	return buf.String(), nil
+}
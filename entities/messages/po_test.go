@@ -0,0 +1,265 @@
+package messages
+
+import (
+	"reflect"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+const testPO = `# Russian translation.
+msgid ""
+msgstr ""
+"Language: ru\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#. key: U-000001
+#: errors.json
+msgctxt "U-000001"
+msgid "User {user_id} not found"
+msgstr "Пользователь {user_id} не найден"
+
+# translator note
+#, fuzzy
+msgctxt "S-000001"
+msgid ""
+"Storage is \"down\".\n"
+"Retry later."
+msgstr "Хранилище недоступно."
+#. key: V-000001
+msgctxt "V-000001"
+msgid "{count} field is invalid"
+msgid_plural "{count} fields are invalid"
+msgstr[0] "{count} поле заполнено неверно"
+msgstr[1] "{count} поля заполнены неверно"
+msgstr[2] "{count} полей заполнено неверно"
+
+#~ msgctxt "X-000001"
+#~ msgid "Gone"
+#~ msgstr "Нет"
+`
+
+func TestParsePO(t *testing.T) {
+	var f, err = ParsePO("ru.po", strings.NewReader(testPO))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := f.Language(); got != "ru" {
+		t.Errorf("Language() = %v, want %v", got, "ru")
+	}
+
+	var want = []*POEntry{
+		{
+			ExtractedComments: []string{"key: U-000001"},
+			References:        []string{"errors.json"},
+			Context:           "U-000001",
+			ID:                "User {user_id} not found",
+			Str:               []string{"Пользователь {user_id} не найден"},
+		},
+		{
+			Comments: []string{"translator note"},
+			Flags:    []string{"fuzzy"},
+			Context:  "S-000001",
+			ID:       "Storage is \"down\".\nRetry later.",
+			Str:      []string{"Хранилище недоступно."},
+		},
+		{
+			ExtractedComments: []string{"key: V-000001"},
+			Context:           "V-000001",
+			ID:                "{count} field is invalid",
+			IDPlural:          "{count} fields are invalid",
+			Str: []string{
+				"{count} поле заполнено неверно",
+				"{count} поля заполнены неверно",
+				"{count} полей заполнено неверно",
+			},
+		},
+		{
+			Context:  "X-000001",
+			ID:       "Gone",
+			Str:      []string{"Нет"},
+			Obsolete: true,
+		},
+	}
+
+	if !reflect.DeepEqual(f.Entries, want) {
+		t.Errorf("ParsePO() entries = %+v, want %+v", f.Entries, want)
+	}
+
+	if e, ok := f.Lookup("S-000001", "Storage is \"down\".\nRetry later."); !ok || e.Translated() {
+		t.Errorf("Lookup() = %v, %v, want fuzzy entry", e, ok)
+	}
+
+	if _, ok := f.Lookup("X-000001", "Gone"); ok {
+		t.Errorf("Lookup() found obsolete entry")
+	}
+
+	var f_ *POFile
+
+	if f_, err = ParsePO("ru.po", strings.NewReader(string(f.Bytes()))); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(f_, f) {
+		t.Errorf("ParsePO(Bytes()) = %+v, want %+v", f_, f)
+	}
+}
+
+func TestPOFile_Bytes_Obsolete(t *testing.T) {
+	var f = &POFile{
+		Entries: []*POEntry{
+			{
+				Context:  "X-000001",
+				ID:       "Storage is down.\nRetry later.",
+				IDPlural: "Storages are down.\nRetry later.",
+				Str:      []string{"Хранилище недоступно.\nПовторите позже.", "Хранилища недоступны."},
+				Obsolete: true,
+			},
+		},
+	}
+
+	var want = `msgid ""
+msgstr ""
+
+#~ msgctxt "X-000001"
+#~ msgid ""
+#~ "Storage is down.\n"
+#~ "Retry later."
+#~ msgid_plural ""
+#~ "Storages are down.\n"
+#~ "Retry later."
+#~ msgstr[0] ""
+#~ "Хранилище недоступно.\n"
+#~ "Повторите позже."
+#~ msgstr[1] "Хранилища недоступны."
+`
+
+	var data = f.Bytes()
+
+	if string(data) != want {
+		t.Fatalf("Bytes() =\n%s\nwant\n%s", data, want)
+	}
+
+	var f_, err = ParsePO("ru.po", strings.NewReader(string(data)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(f_.Entries, f.Entries) {
+		t.Errorf("ParsePO(Bytes()) entries = %+v, want %+v", f_.Entries, f.Entries)
+	}
+}
+
+func TestParsePO_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "Case 1",
+			data:    "msgid \"a\"\nmsgstr[1] \"b\"\n",
+			wantErr: "po: test.po:2: unexpected plural form index 'msgstr[1]'",
+		},
+		{
+			name:    "Case 2",
+			data:    "msgid \"a\nmsgstr \"b\"\n",
+			wantErr: "po: test.po:1: invalid string: string \"a is not quoted",
+		},
+		{
+			name:    "Case 3",
+			data:    "msgid \"a\"\nmsgtext \"b\"\n",
+			wantErr: "po: test.po:2: unknown keyword 'msgtext'",
+		},
+		{
+			name:    "Case 4",
+			data:    "\"a\"\n",
+			wantErr: "po: test.po:1: unexpected string",
+		},
+		{
+			name:    "Case 5",
+			data:    "msgid \"a\\q\"\n",
+			wantErr: "po: test.po:1: invalid string: unknown escape sequence '\\q'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var _, err = ParsePO("test.po", strings.NewReader(tt.data))
+
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParsePO() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSourceOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       types.Message
+		wantSrc Source
+		wantOk  bool
+	}{
+		{
+			name:    "Case 1",
+			m:       new(TextMessage).Text("Storage is unavailable."),
+			wantSrc: Source{Text: "Storage is unavailable."},
+			wantOk:  true,
+		},
+		{
+			name:    "Case 2",
+			m:       new(TemplateMessage).Key("U-000001").Template("User {user_id} not found").Arg("user_id", 1),
+			wantSrc: Source{Key: "U-000001", Text: "User {user_id} not found"},
+			wantOk:  true,
+		},
+		{
+			name: "Case 3",
+			m: new(PluralMessage).
+				Form(PluralOne, "{count} item").
+				Form(PluralOther, "{count} items"),
+			wantSrc: Source{Text: "{count} item", Plural: "{count} items"},
+			wantOk:  true,
+		},
+		{
+			name:    "Case 4",
+			m:       new(LocalizedMessage).Bundle(newTestBundle()).Key("user.not_found"),
+			wantSrc: Source{Key: "user.not_found", Text: "User not found. "},
+			wantOk:  true,
+		},
+		{
+			name: "Case 5",
+			m: new(LocalizedMessage).
+				Bundle(NewBundle("en").Add("en", map[string]string{
+					"items#one":   "{count} item",
+					"items#other": "{count} items",
+				})).
+				Key("items"),
+			wantSrc: Source{Key: "items", Text: "{count} item", Plural: "{count} items"},
+			wantOk:  true,
+		},
+		{
+			name:    "Case 6",
+			m:       new(TextMessage),
+			wantSrc: Source{},
+			wantOk:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSrc, gotOk := SourceOf(tt.m)
+
+			if !reflect.DeepEqual(gotSrc, tt.wantSrc) {
+				t.Errorf("SourceOf() gotSrc = %v, want %v", gotSrc, tt.wantSrc)
+			}
+
+			if gotOk != tt.wantOk {
+				t.Errorf("SourceOf() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package messages
+
+import (
+	"sm-errors/types"
+)
+
+// Source - исходный текст сообщения для перевода.
+type Source struct {
+	// Key - ключ перевода сообщения в наборе, пустой, если у сообщения нет собственного ключа.
+	Key string
+	// Text - исходный текст, для сообщений с формами множественного числа - форма PluralOne.
+	Text string
+	// Plural - форма PluralOther исходного текста, пустая для сообщений без форм множественного числа.
+	Plural string
+}
+
+// SourceOf - получение исходного текста сообщения для перевода.
+// Для локализованных сообщений текст берется из языка по умолчанию набора переводов.
+func SourceOf(m types.Message) (src Source, ok bool) {
+	switch m := m.(type) {
+	case *TextMessage:
+		{
+			src.Text = m.content
+		}
+	case *TemplateMessage:
+		{
+			src.Key = m.key
+			src.Text = m.template
+		}
+	case *PluralMessage:
+		{
+			if src.Text, ok = m.forms[PluralOne]; !ok {
+				src.Text = m.forms[PluralOther]
+			}
+
+			src.Plural = m.forms[PluralOther]
+		}
+	case *LocalizedMessage:
+		{
+			src.Key = m.key
+			src.Text = m.key
+
+			if m.bundle == nil {
+				break
+			}
+
+			if text, _, found := m.bundle.Text(m.key); found {
+				src.Text = text
+				break
+			}
+
+			if text, _, found := m.bundle.Text(PluralKey(m.key, PluralOther)); found {
+				src.Plural = text
+				src.Text = text
+
+				if text, _, found = m.bundle.Text(PluralKey(m.key, PluralOne)); found {
+					src.Text = text
+				}
+			}
+		}
+	default:
+		{
+			return
+		}
+	}
+
+	ok = src.Text != ""
+
+	return
+}
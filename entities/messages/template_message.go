@@ -0,0 +1,347 @@
+package messages
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sm-errors/types"
+	"sort"
+	"strings"
+)
+
+// placeholderRegexp - выражение для поиска именованных параметров шаблона, например {user_id}.
+var placeholderRegexp = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+type (
+	// TemplateMessage - сообщение по шаблону с именованными параметрами, например "User {user_id} not found".
+	// В форматах JSON и XML передаются ключ шаблона, шаблон, аргументы и итоговый текст.
+	TemplateMessage struct {
+		key      string
+		template string
+		params   []string
+		args     map[string]any
+	}
+
+	// templateMessageWrapper - структура обертка для упаковки сообщения по шаблону.
+	templateMessageWrapper struct {
+		Key      string         `json:"key,omitempty"`
+		Template string         `json:"template"`
+		Args     map[string]any `json:"args,omitempty"`
+		Text     string         `json:"text"`
+	}
+
+	// templateMessageXMLWrapper - структура обертка для распаковки сообщения по шаблону из формата XML.
+	templateMessageXMLWrapper struct {
+		Key      string  `xml:"Key"`
+		Template *string `xml:"Template"`
+		Args     []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"Args>Arg"`
+		Text     *string `xml:"Text"`
+		CharData string  `xml:",chardata"`
+	}
+)
+
+// Placeholders - получение списка параметров шаблона в порядке первого появления.
+func Placeholders(template string) (names []string) {
+	var seen = make(map[string]bool)
+
+	for _, m := range placeholderRegexp.FindAllStringSubmatch(template, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+
+	return
+}
+
+// renderTemplate - подстановка аргументов в шаблон.
+// Параметры без аргументов остаются в тексте без изменений.
+func renderTemplate(template string, args map[string]any) (str string) {
+	if len(args) == 0 {
+		return template
+	}
+
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(s string) string {
+		if v, ok := args[s[1:len(s)-1]]; ok {
+			return fmt.Sprint(v)
+		}
+
+		return s
+	})
+}
+
+// String - получение текста сообщения с подставленными аргументами.
+// Параметры без аргументов остаются в тексте без изменений.
+func (m *TemplateMessage) String() (str string) {
+	return renderTemplate(m.template, m.args)
+}
+
+// Kind - получение типа сообщения для передачи между сервисами.
+func (m *TemplateMessage) Kind() (kind string) {
+	return KindTemplate
+}
+
+// Key - установить ключ шаблона, по которому клиенты могут перевести сообщение.
+func (m *TemplateMessage) Key(key string) *TemplateMessage {
+	m.key = key
+	return m
+}
+
+// Template - установить шаблон сообщения.
+func (m *TemplateMessage) Template(template string) *TemplateMessage {
+	m.template = template
+	return m
+}
+
+// Params - объявить параметры шаблона.
+// Объявленные параметры проверяются методом Validate при построении ошибки.
+func (m *TemplateMessage) Params(names ...string) *TemplateMessage {
+	m.params = append(make([]string, 0, len(names)), names...)
+	return m
+}
+
+// Arg - установить значение аргумента шаблона.
+func (m *TemplateMessage) Arg(name string, v any) *TemplateMessage {
+	m.SetArg(name, v)
+	return m
+}
+
+// SetArg - установить значение аргумента шаблона.
+func (m *TemplateMessage) SetArg(name string, v any) {
+	if m.args == nil {
+		m.args = make(map[string]any)
+	}
+
+	m.args[name] = v
+	return
+}
+
+// Args - получение копии аргументов шаблона.
+func (m *TemplateMessage) Args() (args map[string]any) {
+	args = make(map[string]any, len(m.args))
+
+	for k, v := range m.args {
+		args[k] = v
+	}
+
+	return
+}
+
+// Validate - проверка соответствия шаблона, объявленных параметров и аргументов.
+// Если параметры не объявлены, они определяются по шаблону.
+func (m *TemplateMessage) Validate() (err error) {
+	var (
+		placeholders = Placeholders(m.template)
+		declared     = make(map[string]bool)
+		problems     []string
+	)
+
+	if m.params == nil {
+		for _, name := range placeholders {
+			declared[name] = true
+		}
+	} else {
+		var inTemplate = make(map[string]bool)
+
+		for _, name := range placeholders {
+			inTemplate[name] = true
+		}
+
+		for _, name := range m.params {
+			declared[name] = true
+
+			if !inTemplate[name] {
+				problems = append(problems, fmt.Sprintf("extra parameter '%s'", name))
+			}
+		}
+
+		for _, name := range placeholders {
+			if !declared[name] {
+				problems = append(problems, fmt.Sprintf("missing parameter '%s'", name))
+			}
+		}
+	}
+
+	var extra = make([]string, 0)
+
+	for name := range m.args {
+		if !declared[name] {
+			extra = append(extra, name)
+		}
+	}
+
+	sort.Strings(extra)
+
+	for _, name := range extra {
+		problems = append(problems, fmt.Sprintf("extra argument '%s'", name))
+	}
+
+	if len(problems) > 0 {
+		err = fmt.Errorf("template '%s': %s", m.template, strings.Join(problems, ", "))
+	}
+
+	return
+}
+
+// Clone - копирование сообщения.
+func (m *TemplateMessage) Clone() types.Message {
+	var m_ = &TemplateMessage{
+		key:      strings.Clone(m.key),
+		template: strings.Clone(m.template),
+	}
+
+	if m.params != nil {
+		m_.params = append(make([]string, 0, len(m.params)), m.params...)
+	}
+
+	if m.args != nil {
+		m_.args = m.Args()
+	}
+
+	return m_
+}
+
+// MarshalJSON - упаковать в формат JSON.
+func (m *TemplateMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.wrapper())
+}
+
+// UnmarshalJSON - распаковать из формата JSON.
+func (m *TemplateMessage) UnmarshalJSON(data []byte) (err error) {
+	var w = new(templateMessageWrapper)
+
+	if err = json.Unmarshal(data, w); err != nil {
+		return
+	}
+
+	m.key = w.Key
+	m.template = w.Template
+	m.params = nil
+	m.args = w.Args
+
+	return
+}
+
+// MarshalXML - упаковать в формат XML.
+func (m *TemplateMessage) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	var w = m.wrapper()
+
+	if err = e.EncodeToken(start); err != nil {
+		return
+	}
+
+	if w.Key != "" {
+		if err = e.EncodeElement(w.Key, xml.StartElement{Name: xml.Name{Local: "Key"}}); err != nil {
+			return
+		}
+	}
+
+	if err = e.EncodeElement(w.Template, xml.StartElement{Name: xml.Name{Local: "Template"}}); err != nil {
+		return
+	}
+
+	if len(w.Args) > 0 {
+		var argsStart = xml.StartElement{
+			Name: xml.Name{
+				Local: "Args",
+			},
+		}
+
+		if err = e.EncodeToken(argsStart); err != nil {
+			return
+		}
+
+		var names = make([]string, 0, len(w.Args))
+
+		for name := range w.Args {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			var argStart = xml.StartElement{
+				Name: xml.Name{
+					Local: "Arg",
+				},
+				Attr: []xml.Attr{
+					{
+						Name: xml.Name{
+							Local: "name",
+						},
+						Value: name,
+					},
+				},
+			}
+
+			if err = e.EncodeElement(fmt.Sprint(w.Args[name]), argStart); err != nil {
+				return
+			}
+		}
+
+		if err = e.EncodeToken(argsStart.End()); err != nil {
+			return
+		}
+	}
+
+	if err = e.EncodeElement(w.Text, xml.StartElement{Name: xml.Name{Local: "Text"}}); err != nil {
+		return
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML - распаковать из формата XML.
+// Аргументы передаются в XML текстом и распаковываются строками.
+func (m *TemplateMessage) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	var w = new(templateMessageXMLWrapper)
+
+	if err = d.DecodeElement(w, &start); err != nil {
+		return
+	}
+
+	m.fromXMLWrapper(w)
+
+	return
+}
+
+// fromXMLWrapper - заполнить сообщение из структуры обертки формата XML.
+func (m *TemplateMessage) fromXMLWrapper(w *templateMessageXMLWrapper) {
+	m.key = w.Key
+	m.template = ""
+	m.params = nil
+	m.args = nil
+
+	if w.Template != nil {
+		m.template = *w.Template
+	}
+
+	if len(w.Args) > 0 {
+		m.args = make(map[string]any, len(w.Args))
+
+		for _, arg := range w.Args {
+			m.args[arg.Name] = arg.Value
+		}
+	}
+
+	return
+}
+
+// wrapper - получение структуры обертки для упаковки.
+func (m *TemplateMessage) wrapper() (w *templateMessageWrapper) {
+	w = &templateMessageWrapper{
+		Key:      m.key,
+		Template: m.template,
+		Text:     m.String(),
+	}
+
+	if len(m.args) > 0 {
+		w.Args = m.Args()
+	}
+
+	return
+}
@@ -0,0 +1,229 @@
+package messages
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		wantNames []string
+	}{
+		{
+			name:      "Case 1",
+			template:  "User {user_id} not found",
+			wantNames: []string{"user_id"},
+		},
+		{
+			name:      "Case 2",
+			template:  "{a} and {b}, again {a}",
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name:      "Case 3",
+			template:  "No placeholders {1}",
+			wantNames: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotNames := Placeholders(tt.template); !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("Placeholders() = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestTemplateMessage_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *TemplateMessage
+		wantStr string
+	}{
+		{
+			name: "Case 1",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found").
+				Arg("user_id", 42),
+			wantStr: "User 42 not found",
+		},
+		{
+			name: "Case 2",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found"),
+			wantStr: "User {user_id} not found",
+		},
+		{
+			name: "Case 3",
+			m: new(TemplateMessage).
+				Template("{a}{b}{a}").
+				Arg("a", "x").
+				Arg("b", true),
+			wantStr: "xtruex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.m.String(); gotStr != tt.wantStr {
+				t.Errorf("String() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestTemplateMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *TemplateMessage
+		wantErr string
+	}{
+		{
+			name: "Case 1",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found"),
+			wantErr: "",
+		},
+		{
+			name: "Case 2",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found").
+				Params("user_id"),
+			wantErr: "",
+		},
+		{
+			name: "Case 3",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found in {group}").
+				Params("user_id", "attempt"),
+			wantErr: "template 'User {user_id} not found in {group}': extra parameter 'attempt', missing parameter 'group'",
+		},
+		{
+			name: "Case 4",
+			m: new(TemplateMessage).
+				Template("User {user_id} not found").
+				Arg("name", "test"),
+			wantErr: "template 'User {user_id} not found': extra argument 'name'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr string
+
+			if err := tt.m.Validate(); err != nil {
+				gotErr = err.Error()
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplateMessage_Clone(t *testing.T) {
+	var (
+		m = new(TemplateMessage).
+			Key("user.not_found").
+			Template("User {user_id} not found").
+			Arg("user_id", 1)
+		c = m.Clone().(*TemplateMessage)
+	)
+
+	c.SetArg("user_id", 2)
+
+	if got := m.String(); got != "User 1 not found" {
+		t.Errorf("String() = %v, want %v", got, "User 1 not found")
+	}
+
+	if got := c.String(); got != "User 2 not found" {
+		t.Errorf("String() = %v, want %v", got, "User 2 not found")
+	}
+}
+
+func TestTemplateMessage_MarshalJSON(t *testing.T) {
+	var m = new(TemplateMessage).
+		Key("user.not_found").
+		Template("User {user_id} not found").
+		Arg("user_id", "u-1")
+
+	var data, err = json.Marshal(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `{"key":"user.not_found","template":"User {user_id} not found","args":{"user_id":"u-1"},"text":"User u-1 not found"}`
+
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+
+	var m_ = new(TemplateMessage)
+
+	if err = json.Unmarshal(data, m_); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(m_, m) {
+		t.Errorf("UnmarshalJSON() = %v, want %v", m_, m)
+	}
+}
+
+func TestTemplateMessage_MarshalXML(t *testing.T) {
+	var m = new(TemplateMessage).
+		Key("user.not_found").
+		Template("User {user_id} not found").
+		Arg("user_id", 7)
+
+	var data, err = xml.Marshal(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = `<TemplateMessage><Key>user.not_found</Key><Template>User {user_id} not found</Template><Args><Arg name="user_id">7</Arg></Args><Text>User 7 not found</Text></TemplateMessage>`
+
+	if string(data) != want {
+		t.Errorf("MarshalXML() = %s, want %s", data, want)
+	}
+}
+
+func TestTemplateMessage_UnmarshalXML(t *testing.T) {
+	var m = new(TemplateMessage).
+		Key("user.not_found").
+		Template("User {user_id} not found").
+		Arg("user_id", 7)
+
+	var data, err = xml.Marshal(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m_ = new(TemplateMessage)
+
+	if err = xml.Unmarshal(data, m_); err != nil {
+		t.Fatal(err)
+	}
+
+	// Аргументы распаковываются строками.
+	var want = new(TemplateMessage).
+		Key("user.not_found").
+		Template("User {user_id} not found").
+		Arg("user_id", "7")
+
+	if !reflect.DeepEqual(m_, want) {
+		t.Errorf("UnmarshalXML() = %v, want %v", m_, want)
+	}
+
+	if m_.String() != m.String() {
+		t.Errorf("UnmarshalXML() text = %q, want %q", m_.String(), m.String())
+	}
+}
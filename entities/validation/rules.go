@@ -0,0 +1,341 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Правила проверки, указываемые в теге validate.
+const (
+	RuleRequired  = "required"
+	RuleOmitEmpty = "omitempty"
+	RuleMin       = "min"
+	RuleMax       = "max"
+	RuleLen       = "len"
+	RulePattern   = "pattern"
+	RuleEmail     = "email"
+	RuleOneOf     = "oneof"
+	RuleDive      = "dive"
+)
+
+type (
+	// rule - правило проверки значения.
+	rule struct {
+		code  string
+		param any
+
+		size    bool
+		number  float64
+		pattern *regexp.Regexp
+		values  []string
+	}
+)
+
+// parseRules - разбор правил тега для значения типа t.
+// Правила после каждого "dive" относятся к элементам коллекции следующего уровня.
+func parseRules(tag string, t reflect.Type) (levels [][]*rule, omitEmpty bool, err error) {
+	levels = [][]*rule{{}}
+
+	for _, part := range splitTag(tag) {
+		var code, param, _ = strings.Cut(part, "=")
+
+		if code == "" {
+			continue
+		}
+
+		switch code {
+		case RuleOmitEmpty:
+			{
+				if len(levels) == 1 {
+					omitEmpty = true
+				}
+
+				continue
+			}
+		case RuleDive:
+			{
+				if t = indirectType(t); t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+					return nil, false, fmt.Errorf("rule '%s' requires slice, array or map, got %s", RuleDive, t)
+				}
+
+				t = t.Elem()
+				levels = append(levels, []*rule{})
+
+				continue
+			}
+		}
+
+		var r *rule
+
+		if r, err = newRule(code, param, indirectType(t)); err != nil {
+			return nil, false, err
+		}
+
+		levels[len(levels)-1] = append(levels[len(levels)-1], r)
+	}
+
+	return
+}
+
+// splitTag - разбиение тега на правила по запятым, "\," не разделяет правила.
+func splitTag(tag string) (parts []string) {
+	var b strings.Builder
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			{
+				b.WriteByte(',')
+				i++
+			}
+		case tag[i] == ',':
+			{
+				parts = append(parts, strings.TrimSpace(b.String()))
+				b.Reset()
+			}
+		default:
+			b.WriteByte(tag[i])
+		}
+	}
+
+	return append(parts, strings.TrimSpace(b.String()))
+}
+
+// newRule - создание правила с проверкой параметра и применимости к типу t.
+func newRule(code, param string, t reflect.Type) (r *rule, err error) {
+	r = &rule{code: code}
+
+	switch code {
+	case RuleRequired, RuleEmail:
+		{
+			if param != "" {
+				return nil, fmt.Errorf("rule '%s' has no parameter", code)
+			}
+
+			if code == RuleEmail && t.Kind() != reflect.String {
+				return nil, fmt.Errorf("rule '%s' requires string, got %s", code, t)
+			}
+		}
+	case RuleMin, RuleMax, RuleLen:
+		{
+			switch t.Kind() {
+			case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+				{
+					var n int
+
+					if n, err = strconv.Atoi(param); err != nil || n < 0 {
+						return nil, fmt.Errorf("rule '%s' requires non-negative integer, got '%s'", code, param)
+					}
+
+					r.size, r.number, r.param = true, float64(n), n
+				}
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				{
+					if code == RuleLen {
+						return nil, fmt.Errorf("rule '%s' requires string, slice, array or map, got %s", code, t)
+					}
+
+					if r.number, err = strconv.ParseFloat(param, 64); err != nil {
+						return nil, fmt.Errorf("rule '%s' requires number, got '%s'", code, param)
+					}
+
+					if i, err := strconv.ParseInt(param, 10, 64); err == nil {
+						r.param = i
+					} else {
+						r.param = r.number
+					}
+				}
+			default:
+				return nil, fmt.Errorf("rule '%s' is not applicable to %s", code, t)
+			}
+		}
+	case RulePattern:
+		{
+			if t.Kind() != reflect.String {
+				return nil, fmt.Errorf("rule '%s' requires string, got %s", code, t)
+			}
+
+			if r.pattern, err = regexp.Compile(param); err != nil {
+				return nil, fmt.Errorf("rule '%s': %w", code, err)
+			}
+
+			r.param = param
+		}
+	case RuleOneOf:
+		{
+			if r.values = strings.Fields(param); len(r.values) == 0 {
+				return nil, fmt.Errorf("rule '%s' requires values", code)
+			}
+
+			switch t.Kind() {
+			case reflect.String,
+				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			default:
+				return nil, fmt.Errorf("rule '%s' requires string or integer, got %s", code, t)
+			}
+
+			r.param = r.values
+		}
+	default:
+		return nil, fmt.Errorf("unknown rule '%s'", code)
+	}
+
+	return
+}
+
+// check - проверка значения правилом.
+func (r *rule) check(v reflect.Value) (ok bool) {
+	if r.code == RuleRequired {
+		return !isEmpty(v)
+	}
+
+	// Остальные правила не проверяют отсутствующие значения.
+	if v = indirect(v); !v.IsValid() {
+		return true
+	}
+
+	switch r.code {
+	case RuleMin:
+		return r.measure(v) >= r.number
+	case RuleMax:
+		return r.measure(v) <= r.number
+	case RuleLen:
+		return r.measure(v) == r.number
+	case RulePattern:
+		return r.pattern.MatchString(v.String())
+	case RuleEmail:
+		{
+			var address, err = mail.ParseAddress(v.String())
+
+			return err == nil && address.Name == "" && address.Address == v.String()
+		}
+	case RuleOneOf:
+		return slices.Contains(r.values, formatValue(v))
+	}
+
+	return true
+}
+
+// measure - получение длины или значения для сравнения с параметром правила.
+func (r *rule) measure(v reflect.Value) (n float64) {
+	if r.size {
+		if v.Kind() == reflect.String {
+			return float64(utf8.RuneCountInString(v.String()))
+		}
+
+		return float64(v.Len())
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	}
+
+	return v.Float()
+}
+
+// template - получение шаблона сообщения о нарушении правила.
+func (r *rule) template() (key, template string) {
+	switch r.code {
+	case RuleRequired:
+		return "validation.required", "Value is required"
+	case RuleMin:
+		{
+			if r.size {
+				return "validation.min_length", "Length must be at least {min}"
+			}
+
+			return "validation.min", "Value must be at least {min}"
+		}
+	case RuleMax:
+		{
+			if r.size {
+				return "validation.max_length", "Length must be at most {max}"
+			}
+
+			return "validation.max", "Value must be at most {max}"
+		}
+	case RuleLen:
+		return "validation.len", "Length must be exactly {len}"
+	case RulePattern:
+		return "validation.pattern", "Value must match pattern {pattern}"
+	case RuleEmail:
+		return "validation.email", "Value must be a valid email address"
+	case RuleOneOf:
+		return "validation.oneof", "Value must be one of {oneof}"
+	}
+
+	return "validation." + r.code, "Value is invalid"
+}
+
+// formatValue - получение строкового представления значения.
+// Значения, полученные через неэкспортируемые поля, форматируются без вызова Interface.
+func formatValue(v reflect.Value) (str string) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+
+	return v.String()
+}
+
+// isEmpty - проверка, что значение отсутствует или пустое.
+func isEmpty(v reflect.Value) (ok bool) {
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+// indirect - получение значения по указателю, для nil возвращается пустое значение.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// indirectType - получение типа значения по указателю.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
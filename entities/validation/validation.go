@@ -0,0 +1,304 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TagName - имя тега с правилами проверки поля структуры.
+const TagName = "validate"
+
+// maxWalkDepth - максимальная глубина вложенности коллекций при проверке типа.
+const maxWalkDepth = 32
+
+// ErrInvalidTag - ошибка разбора правил проверки в теге структуры.
+var ErrInvalidTag = errors.New("validation: invalid tag")
+
+type (
+	// structInfo - разобранные правила полей структуры.
+	structInfo struct {
+		fields []*fieldInfo
+		err    error
+	}
+
+	// fieldInfo - разобранные правила поля структуры.
+	fieldInfo struct {
+		index     int
+		name      string
+		inline    bool
+		levels    [][]*rule
+		omitEmpty bool
+	}
+
+	// walker - обход значения с накоплением нарушений по ключам полей.
+	walker struct {
+		fields  []types.DetailsField
+		index   map[string]int
+		visited map[reference]bool
+		err     error
+	}
+
+	// reference - указатель, карта или срез на пути обхода.
+	reference struct {
+		ptr uintptr
+		typ reflect.Type
+	}
+)
+
+// structs - кеш разобранных правил структур.
+var structs = struct {
+	info  map[reflect.Type]*structInfo
+	rwMux *sync.RWMutex
+}{
+	info:  make(map[reflect.Type]*structInfo),
+	rwMux: new(sync.RWMutex),
+}
+
+// Validate - проверка значения по правилам из тегов validate.
+// Обходятся структуры, указатели, срезы, массивы и карты: ключи полей строятся по именам
+// из тега json (или имени поля), индексам массивов и ключам карт, например "user.items[3].name".
+// Правила перечисляются через запятую: required, omitempty, min=N, max=N, len=N, pattern=RE,
+// email, oneof=A B C; правила после dive применяются к элементам коллекции.
+// Для каждого поля с нарушениями возвращается types.DetailsField с сообщением первого
+// нарушения и списком всех нарушений. Ошибка возвращается при некорректных правилах.
+func Validate(v any) (fields []types.DetailsField, err error) {
+	var w = &walker{
+		index:   make(map[string]int),
+		visited: make(map[reference]bool),
+	}
+
+	w.value(reflect.ValueOf(v), new(details.FieldKey), nil, false)
+
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	return w.fields, nil
+}
+
+// value - проверка значения и обход вложенных значений.
+func (w *walker) value(v reflect.Value, key types.DetailsFieldKey, levels [][]*rule, omitEmpty bool) {
+	if w.err != nil || omitEmpty && isEmpty(v) {
+		return
+	}
+
+	if len(levels) > 0 {
+		for _, r := range levels[0] {
+			if !r.check(v) {
+				w.violation(key, r)
+			}
+		}
+
+		levels = levels[1:]
+	}
+
+	// Значение, уже обходимое выше по пути, образует цикл и повторно не обходится.
+	if ref, ok := referenceOf(v); ok {
+		if w.visited[ref] {
+			return
+		}
+
+		w.visited[ref] = true
+		defer delete(w.visited, ref)
+	}
+
+	if v = indirect(v); !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		w.structValue(v, key)
+	case reflect.Slice, reflect.Array:
+		{
+			if len(levels) == 0 && !walkable(v.Type().Elem(), 0) {
+				return
+			}
+
+			for i := 0; i < v.Len(); i++ {
+				w.value(v.Index(i), key.Clone().AddArray("", i), levels, false)
+			}
+		}
+	case reflect.Map:
+		{
+			if len(levels) == 0 && !walkable(v.Type().Elem(), 0) {
+				return
+			}
+
+			var keys = v.MapKeys()
+
+			sort.Slice(keys, func(i, j int) bool {
+				return formatValue(keys[i]) < formatValue(keys[j])
+			})
+
+			for _, k := range keys {
+				w.value(v.MapIndex(k), key.Clone().AddMap("", formatValue(k)), levels, false)
+			}
+		}
+	}
+
+	return
+}
+
+// structValue - обход полей структуры.
+func (w *walker) structValue(v reflect.Value, key types.DetailsFieldKey) {
+	var info = structInfoOf(v.Type())
+
+	if info.err != nil {
+		w.err = info.err
+		return
+	}
+
+	for _, f := range info.fields {
+		var fieldKey = key
+
+		if !f.inline {
+			fieldKey = key.Clone().Add(f.name)
+		}
+
+		w.value(v.Field(f.index), fieldKey, f.levels, f.omitEmpty)
+	}
+
+	return
+}
+
+// violation - добавить нарушение правила поля.
+func (w *walker) violation(key types.DetailsFieldKey, r *rule) {
+	var (
+		templateKey, template = r.template()
+		m                     = new(messages.TemplateMessage).Key(templateKey).Template(template)
+		violation             = types.DetailsFieldViolation{
+			Code:    r.code,
+			Message: m,
+		}
+	)
+
+	if r.param != nil {
+		violation.Params = map[string]any{r.code: r.param}
+		m.Arg(r.code, r.param)
+	}
+
+	if i, ok := w.index[key.String()]; ok {
+		w.fields[i].Violations = append(w.fields[i].Violations, violation)
+		return
+	}
+
+	w.index[key.String()] = len(w.fields)
+	w.fields = append(w.fields, types.DetailsField{
+		Key:        key,
+		Message:    m,
+		Violations: []types.DetailsFieldViolation{violation},
+	})
+
+	return
+}
+
+// referenceOf - получение ссылки значения-указателя, карты или среза.
+func referenceOf(v reflect.Value) (ref reference, ok bool) {
+	for v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		{
+			if v.IsNil() {
+				return
+			}
+
+			return reference{ptr: v.Pointer(), typ: v.Type()}, true
+		}
+	}
+
+	return
+}
+
+// walkable - проверка, нужно ли обходить значения типа в поисках вложенных правил.
+// Глубина ограничивает проверку рекурсивных типов коллекций.
+func walkable(t reflect.Type, depth int) (ok bool) {
+	if depth > maxWalkDepth {
+		return
+	}
+
+	switch t = indirectType(t); t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return walkable(t.Elem(), depth+1)
+	}
+
+	return
+}
+
+// structInfoOf - получение разобранных правил структуры из кеша.
+func structInfoOf(t reflect.Type) (info *structInfo) {
+	structs.rwMux.RLock()
+	info, ok := structs.info[t]
+	structs.rwMux.RUnlock()
+
+	if ok {
+		return
+	}
+
+	info = parseStruct(t)
+
+	structs.rwMux.Lock()
+	defer structs.rwMux.Unlock()
+
+	structs.info[t] = info
+
+	return
+}
+
+// parseStruct - разбор правил полей структуры.
+func parseStruct(t reflect.Type) (info *structInfo) {
+	info = new(structInfo)
+
+	for i := 0; i < t.NumField(); i++ {
+		var sf = t.Field(i)
+
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		var name, _, _ = strings.Cut(sf.Tag.Get("json"), ",")
+
+		if name == "-" {
+			continue
+		}
+
+		var f = &fieldInfo{
+			index:  i,
+			name:   name,
+			inline: sf.Anonymous && name == "" && indirectType(sf.Type).Kind() == reflect.Struct,
+		}
+
+		if f.name == "" {
+			f.name = sf.Name
+		}
+
+		var tag, hasTag = sf.Tag.Lookup(TagName)
+
+		if !hasTag && !walkable(sf.Type, 0) || !sf.IsExported() && !f.inline {
+			continue
+		}
+
+		var err error
+
+		if f.levels, f.omitEmpty, err = parseRules(tag, sf.Type); err != nil {
+			info.err = fmt.Errorf("%w: %s.%s: %w", ErrInvalidTag, t, sf.Name, err)
+			return
+		}
+
+		info.fields = append(info.fields, f)
+	}
+
+	return
+}
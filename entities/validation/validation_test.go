@@ -0,0 +1,287 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"sm-errors/types"
+	"testing"
+)
+
+type (
+	// testAddress - адрес для тестов вложенных структур.
+	testAddress struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `json:"zip"  validate:"omitempty,len=6,pattern=^[0-9]+$"`
+	}
+
+	// testItem - элемент для тестов массивов.
+	testItem struct {
+		Name  string `json:"name"  validate:"required,max=5"`
+		Count int    `json:"count" validate:"min=1"`
+	}
+
+	// testMeta - встраиваемая структура для тестов.
+	testMeta struct {
+		Source string `json:"source" validate:"oneof=web mobile"`
+	}
+
+	// testRequest - запрос для тестов проверки.
+	testRequest struct {
+		testMeta
+
+		Email    string               `json:"email"    validate:"required,email"`
+		Password string               `json:"password" validate:"min=8,pattern=[0-9]"`
+		Age      *int                 `json:"age"      validate:"omitempty,min=18,max=130"`
+		Address  testAddress          `json:"address"`
+		Items    []testItem           `json:"items"    validate:"min=1"`
+		Tags     []string             `json:"tags"     validate:"max=3,dive,required,min=2"`
+		Labels   map[string]string    `json:"labels"   validate:"dive,max=3"`
+		Contacts map[string]*testItem `json:"contacts"`
+		Skipped  string               `json:"-"        validate:"required"`
+		internal string
+	}
+
+	// testNode - узел со ссылками на себя для тестов циклов.
+	testNode struct {
+		Name     string         `json:"name"     validate:"required"`
+		Next     *testNode      `json:"next"`
+		Children []*testNode    `json:"children"`
+		Extra    map[string]any `json:"extra"`
+	}
+)
+
+// violationCodes - получение ключей и кодов нарушений полей.
+func violationCodes(fields []types.DetailsField) (codes map[string][]string) {
+	codes = make(map[string][]string)
+
+	for _, f := range fields {
+		for _, v := range f.Violations {
+			codes[f.Key.String()] = append(codes[f.Key.String()], v.Code)
+		}
+	}
+
+	return
+}
+
+func TestValidate(t *testing.T) {
+	var age = 12
+
+	tests := []struct {
+		name      string
+		v         any
+		wantCodes map[string][]string
+	}{
+		{
+			name: "Case 1",
+			v: &testRequest{
+				testMeta: testMeta{Source: "web"},
+				Email:    "user@example.com",
+				Password: "secret123",
+				Address:  testAddress{City: "Moscow"},
+				Items:    []testItem{{Name: "pen", Count: 1}},
+			},
+			wantCodes: map[string][]string{},
+		},
+		{
+			name: "Case 2",
+			v: testRequest{
+				testMeta: testMeta{Source: "tv"},
+				Email:    "not an email",
+				Password: "short",
+				Age:      &age,
+				Address:  testAddress{Zip: "12a"},
+				Items:    []testItem{{Name: "pen", Count: 1}, {Name: "notebook"}},
+				Tags:     []string{"a", "", "go", "ok"},
+				Labels:   map[string]string{"env": "production", "a.b": "ok"},
+				Contacts: map[string]*testItem{"home": {Count: 1}, "work": nil},
+			},
+			wantCodes: map[string][]string{
+				"source":              {RuleOneOf},
+				"email":               {RuleEmail},
+				"password":            {RuleMin, RulePattern},
+				"age":                 {RuleMin},
+				"address.city":        {RuleRequired},
+				"address.zip":         {RuleLen, RulePattern},
+				"items[1].name":       {RuleMax},
+				"items[1].count":      {RuleMin},
+				"tags":                {RuleMax},
+				"tags[0]":             {RuleMin},
+				"tags[1]":             {RuleRequired, RuleMin},
+				"labels[env]":         {RuleMax},
+				"contacts[home].name": {RuleRequired},
+			},
+		},
+		{
+			name: "Case 3",
+			v:    []testItem{{Name: "pen", Count: 1}, {Count: 1}},
+			wantCodes: map[string][]string{
+				"[1].name": {RuleRequired},
+			},
+		},
+		{
+			name:      "Case 4",
+			v:         nil,
+			wantCodes: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := Validate(tt.v)
+
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if got := violationCodes(fields); !reflect.DeepEqual(got, tt.wantCodes) {
+				t.Errorf("Validate() = %v, want %v", got, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestValidate_Field(t *testing.T) {
+	var fields, err = Validate(struct {
+		Items []struct {
+			Password string `json:"password" validate:"min=8,pattern=[0-9]"`
+		} `json:"items"`
+	}{
+		Items: []struct {
+			Password string `json:"password" validate:"min=8,pattern=[0-9]"`
+		}{{Password: "abc"}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 1 {
+		t.Fatalf("Validate() = %v, want 1 field", fields)
+	}
+
+	var f = fields[0]
+
+	if want := "/items/0/password"; f.Key.JSONPointer() != want {
+		t.Errorf("Validate() key = %v, want %v", f.Key.JSONPointer(), want)
+	}
+
+	if want := "Length must be at least 8"; f.Message.String() != want {
+		t.Errorf("Validate() message = %v, want %v", f.Message, want)
+	}
+
+	var want = []types.DetailsFieldViolation{
+		{Code: RuleMin, Params: map[string]any{RuleMin: 8}},
+		{Code: RulePattern, Params: map[string]any{RulePattern: "[0-9]"}},
+	}
+
+	for i := range f.Violations {
+		f.Violations[i].Message = nil
+	}
+
+	if !reflect.DeepEqual(f.Violations, want) {
+		t.Errorf("Validate() violations = %v, want %v", f.Violations, want)
+	}
+}
+
+func TestValidate_Cycle(t *testing.T) {
+	var (
+		shared = &testNode{}
+		node   = &testNode{
+			Extra: make(map[string]any),
+		}
+	)
+
+	node.Next = node
+	node.Children = []*testNode{node, shared, shared}
+	node.Extra["self"] = node.Extra
+	shared.Next = node
+
+	var fields, err = Validate(node)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = map[string][]string{
+		"name":             {RuleRequired},
+		"children[1].name": {RuleRequired},
+		"children[2].name": {RuleRequired},
+	}
+
+	if got := violationCodes(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
+func TestValidate_InvalidTag(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{
+			name: "Case 1",
+			v: struct {
+				Name string `validate:"unknown"`
+			}{},
+		},
+		{
+			name: "Case 2",
+			v: struct {
+				Count int `validate:"len=3"`
+			}{},
+		},
+		{
+			name: "Case 3",
+			v: struct {
+				Name string `validate:"pattern=[a-"`
+			}{},
+		},
+		{
+			name: "Case 4",
+			v: struct {
+				Name string `validate:"dive,required"`
+			}{},
+		},
+		{
+			name: "Case 5",
+			v: struct {
+				Name string `validate:"min=abc"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Validate(tt.v); !errors.Is(err, ErrInvalidTag) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidTag)
+			}
+		})
+	}
+}
+
+func Test_splitTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tag       string
+		wantParts []string
+	}{
+		{
+			name:      "Case 1",
+			tag:       "required, min=3",
+			wantParts: []string{"required", "min=3"},
+		},
+		{
+			name:      "Case 2",
+			tag:       `pattern=^[a-z]{1\,3}$,max=3`,
+			wantParts: []string{"pattern=^[a-z]{1,3}$", "max=3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotParts := splitTag(tt.tag); !reflect.DeepEqual(gotParts, tt.wantParts) {
+				t.Errorf("splitTag() = %v, want %v", gotParts, tt.wantParts)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"sm-errors/helpers"
 	"sm-errors/types"
 )
@@ -14,12 +15,19 @@ type (
 		Status() (s types.Status)
 		Message() (m string)
 		Details() (details types.Details)
+		StackTrace() (st types.StackTrace)
+		Context() (ctx context.Context)
 
 		SetError(err error)
+		JoinErrors(errs ...error)
 		SetMessage(m types.Message)
+		SetMessageArgs(args map[string]any)
+		WithContext(ctx context.Context)
+		SetLocales(locales ...string)
 
 		helpers.Error
 		helpers.Stringer
+		helpers.Formatter
 		helpers.Serialization
 	}
 
@@ -40,5 +48,7 @@ type (
 	// Grpc - описание grpc ошибки.
 	Grpc interface {
 		Error
+
+		StatusCode() (c int)
 	}
 )
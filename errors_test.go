@@ -1,13 +1,18 @@
 package errors
 
 import (
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"sm-errors/entities/details"
+	"sm-errors/entities/extractors"
 	"sm-errors/entities/messages"
 	"sm-errors/types"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Примеры базовой ошибки.
@@ -192,3 +197,173 @@ func Test(t *testing.T) {
 		fmt.Printf("%s\n", data)
 	}
 }
+
+func TestError_StdErrors(t *testing.T) {
+	var cause = errors.New("Cause. ")
+
+	tests := []struct {
+		name string
+		err  Error
+	}{
+		{
+			name: "Case 1",
+			err:  ExampleError(),
+		},
+		{
+			name: "Case 2",
+			err:  ExampleRestAPIError(),
+		},
+		{
+			name: "Case 3",
+			err:  ExampleWebSocketError(),
+		},
+		{
+			name: "Case 4",
+			err:  ExampleGrpcError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.err.SetError(cause)
+
+			var wrapped = fmt.Errorf("wrapped: %w", tt.err)
+
+			if !errors.Is(wrapped, cause) {
+				t.Errorf("errors.Is() cause = false, want true")
+			}
+
+			if !errors.Is(wrapped, ExampleError()) {
+				t.Errorf("errors.Is() same id = false, want true")
+			}
+
+			if errors.Is(wrapped, ExampleErrorWithDetails()) {
+				t.Errorf("errors.Is() other id = true, want false")
+			}
+
+			var target Error
+
+			if !errors.As(wrapped, &target) || target.ID() != tt.err.ID() {
+				t.Errorf("errors.As() = %v, want %v", target, tt.err)
+			}
+		})
+	}
+}
+
+func TestError_WithContext(t *testing.T) {
+	type contextKey string
+
+	RegisterContextExtractors(func(ctx context.Context, d types.Details) {
+		if v := ctx.Value(contextKey("trace_id")); v != nil {
+			d.Set("trace_id", v)
+		}
+	})
+	defer ResetContextExtractors()
+
+	var ctx = context.WithValue(context.Background(), contextKey("trace_id"), "TR-1")
+
+	tests := []struct {
+		name string
+		err  Error
+	}{
+		{
+			name: "Case 1",
+			err:  ExampleError(),
+		},
+		{
+			name: "Case 2",
+			err:  ExampleRestAPIError(),
+		},
+		{
+			name: "Case 3",
+			err:  ExampleWebSocketError(),
+		},
+		{
+			name: "Case 4",
+			err:  ExampleGrpcError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.err.WithContext(ctx)
+
+			if tt.err.Context() != ctx {
+				t.Errorf("Context() = %v, want %v", tt.err.Context(), ctx)
+			}
+
+			if v := tt.err.Details().Peek("trace_id"); v != "TR-1" {
+				t.Errorf("Details().Peek() = %v, want %v", v, "TR-1")
+			}
+		})
+	}
+}
+
+func TestError_WithContext_Serialization(t *testing.T) {
+	type contextKey string
+
+	RegisterContextExtractors(
+		extractors.Value(contextKey("request_id"), "request_id"),
+		extractors.Deadline("deadline_ms"),
+		func(ctx context.Context, d types.Details) {
+			if attempt, ok := ctx.Value(contextKey("attempt")).(*int); ok {
+				d.Set("attempt", *attempt)
+			}
+		},
+	)
+	defer ResetContextExtractors()
+
+	var (
+		attempt     = 1
+		ctx, cancel = context.WithTimeout(context.Background(), time.Hour)
+		err         = ExampleRestAPIError()
+	)
+
+	defer cancel()
+
+	ctx = context.WithValue(ctx, contextKey("request_id"), "R-1")
+	ctx = context.WithValue(ctx, contextKey("attempt"), &attempt)
+
+	// Без контекста данные контекста в детали не попадают.
+	if data, _ := json.Marshal(err); strings.Contains(string(data), "request_id") {
+		t.Errorf("MarshalJSON() = %s, want no request_id before WithContext", data)
+	}
+
+	err.WithContext(ctx)
+
+	var attached = err.Details().Peek("deadline_ms").(int64)
+
+	// Значения, изменившиеся после установки контекста, переносятся при упаковке.
+	attempt = 2
+	time.Sleep(20 * time.Millisecond)
+
+	var (
+		data, _ = json.Marshal(err)
+		got     struct {
+			Details struct {
+				RequestID  string  `json:"request_id"`
+				Attempt    int     `json:"attempt"`
+				DeadlineMs float64 `json:"deadline_ms"`
+			} `json:"details"`
+		}
+	)
+
+	if jErr := json.Unmarshal(data, &got); jErr != nil {
+		t.Fatal(jErr)
+	}
+
+	if got.Details.RequestID != "R-1" || got.Details.Attempt != 2 {
+		t.Errorf("MarshalJSON() details = %s, want request_id R-1 and attempt 2", data)
+	}
+
+	if got.Details.DeadlineMs >= float64(attached) {
+		t.Errorf("MarshalJSON() deadline_ms = %v, want less than %v", got.Details.DeadlineMs, attached)
+	}
+
+	attempt = 3
+
+	if data, _ = xml.Marshal(err); !strings.Contains(string(data), `<Item key="request_id">R-1</Item>`) ||
+		!strings.Contains(string(data), `<Item key="attempt">3</Item>`) {
+		t.Errorf("MarshalXML() = %s, want request_id R-1 and attempt 3", data)
+	}
+}
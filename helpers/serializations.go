@@ -13,6 +13,7 @@ type (
 		xml.Marshaler
 
 		json.Unmarshaler
+		xml.Unmarshaler
 	}
 
 	// Stringer - описание методов для преобразование в строку.
@@ -20,8 +21,16 @@ type (
 		fmt.Stringer
 	}
 
+	// Formatter - описание методов для форматирования через пакет fmt.
+	Formatter interface {
+		fmt.Formatter
+	}
+
 	// Error - описание методов для связи с builtin ошибкой.
 	Error interface {
 		error
+
+		Unwrap() (err error)
+		Is(target error) (ok bool)
 	}
 )
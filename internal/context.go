@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"context"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"sync"
+)
+
+// contextExtractors - глобальный список функций переноса данных из контекста.
+var contextExtractors = struct {
+	list  []types.ContextExtractor
+	rwMux sync.RWMutex
+}{}
+
+// RegisterContextExtractors - зарегистрировать функции переноса данных из контекста в детали ошибок.
+// Функции применяются в WithContext и повторно при упаковке ошибки.
+func RegisterContextExtractors(extractors ...types.ContextExtractor) {
+	contextExtractors.rwMux.Lock()
+	defer contextExtractors.rwMux.Unlock()
+
+	for _, ex := range extractors {
+		if ex != nil {
+			contextExtractors.list = append(contextExtractors.list, ex)
+		}
+	}
+
+	return
+}
+
+// ResetContextExtractors - сбросить зарегистрированные функции переноса данных из контекста.
+func ResetContextExtractors() {
+	contextExtractors.rwMux.Lock()
+	defer contextExtractors.rwMux.Unlock()
+
+	contextExtractors.list = nil
+
+	return
+}
+
+// ContextExtractors - получение зарегистрированных функций переноса данных из контекста.
+func ContextExtractors() (list []types.ContextExtractor) {
+	contextExtractors.rwMux.RLock()
+	defer contextExtractors.rwMux.RUnlock()
+
+	list = append(list, contextExtractors.list...)
+
+	return
+}
+
+// WithContext - установить контекст ошибки.
+// Данные контекста переносятся в детали ошибки зарегистрированными функциями переноса,
+// сообщения ошибки и полей локализуются на предпочтительные языки из контекста.
+func (i *Internal) WithContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	i.ctx = ctx
+
+	if locales := messages.LocalesFromContext(ctx); len(locales) > 0 {
+		i.SetLocales(locales...)
+	}
+
+	i.extractContext()
+
+	return
+}
+
+// extractContext - перенос данных контекста ошибки в детали зарегистрированными функциями переноса.
+// Вызывается при установке контекста и при упаковке ошибки, поэтому в деталях оказываются
+// значения контекста на момент упаковки, например оставшееся до крайнего срока время.
+func (i *Internal) extractContext() {
+	var extractors = ContextExtractors()
+
+	if len(extractors) == 0 {
+		return
+	}
+
+	if i.Store.Details == nil {
+		i.Store.Details = new(details.Details)
+	}
+
+	var ctx = i.Context()
+
+	for _, ex := range extractors {
+		ex(ctx, i.Store.Details)
+	}
+
+	return
+}
+
+// Context - получение контекста ошибки.
+func (i *Internal) Context() (ctx context.Context) {
+	if ctx = i.ctx; ctx == nil {
+		ctx = context.Background()
+	}
+
+	return
+}
+
+// SetLocales - установить предпочтительные языки для сообщения ошибки и сообщений полей.
+func (i *Internal) SetLocales(locales ...string) {
+	if m, ok := i.Store.Message.(types.LocalizedMessage); ok {
+		m.SetLocales(locales...)
+	}
+
+	switch d := i.Store.Details.(type) {
+	case nil:
+		return
+	case *details.Details:
+		{
+			d.SetLocales(locales...)
+		}
+	default:
+		{
+			// Сообщения полей копируются, поля заменяются вызовом деталей под их блокировкой.
+			var fields = d.Fields()
+
+			for j := range fields {
+				fields[j].Message = details.LocalizeMessage(fields[j].Message, locales...)
+
+				for k := range fields[j].Violations {
+					fields[j].Violations[k].Message = details.LocalizeMessage(fields[j].Violations[k].Message, locales...)
+				}
+			}
+
+			d.SetFields(fields...)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"testing"
+)
+
+type testContextKey string
+
+func Test_Internal_WithContext(t *testing.T) {
+	var extractor = func(ctx context.Context, d types.Details) {
+		if v := ctx.Value(testContextKey("request_id")); v != nil {
+			d.Set("request_id", v)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		details     types.Details
+		extractors  []types.ContextExtractor
+		wantCtx     context.Context
+		wantDetails types.Details
+	}{
+		{
+			name:        "Case 1",
+			ctx:         nil,
+			details:     new(details.Details),
+			extractors:  nil,
+			wantCtx:     context.Background(),
+			wantDetails: new(details.Details),
+		},
+		{
+			name:       "Case 2",
+			ctx:        context.WithValue(context.Background(), testContextKey("request_id"), "R-1"),
+			details:    new(details.Details).Set("key", "value"),
+			extractors: []types.ContextExtractor{extractor},
+			wantDetails: new(details.Details).
+				Set("key", "value").
+				Set("request_id", "R-1"),
+		},
+		{
+			name:       "Case 3",
+			ctx:        context.WithValue(context.Background(), testContextKey("request_id"), "R-2"),
+			details:    nil,
+			extractors: []types.ContextExtractor{extractor},
+			wantDetails: new(details.Details).
+				Set("request_id", "R-2"),
+		},
+		{
+			name:        "Case 4",
+			ctx:         context.Background(),
+			details:     new(details.Details),
+			extractors:  []types.ContextExtractor{extractor},
+			wantCtx:     context.Background(),
+			wantDetails: new(details.Details),
+		},
+	}
+
+	defer ResetContextExtractors()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetContextExtractors()
+			RegisterContextExtractors(tt.extractors...)
+
+			var i = New(&Store{
+				ID: "T-000001",
+				Message: new(messages.TextMessage).
+					Text("Test error. "),
+				Details: tt.details,
+			})
+
+			i.WithContext(tt.ctx)
+
+			var wantCtx = tt.wantCtx
+
+			if wantCtx == nil {
+				wantCtx = tt.ctx
+			}
+
+			if gotCtx := i.Context(); gotCtx != wantCtx {
+				t.Errorf("Context() = %v, want %v", gotCtx, wantCtx)
+			}
+
+			if gotDetails := i.Details(); !reflect.DeepEqual(gotDetails, tt.wantDetails) {
+				t.Errorf("Details() = %v, want %v", gotDetails, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func Test_Internal_Context(t *testing.T) {
+	var i = &Internal{
+		Store: new(Store),
+	}
+
+	if ctx := i.Context(); ctx != context.Background() {
+		t.Errorf("Context() = %v, want %v", ctx, context.Background())
+	}
+}
+
+func Test_Internal_WithContext_Locales(t *testing.T) {
+	var bundle = messages.NewBundle("en").
+		Add("en", map[string]string{
+			"user.not_found": "User not found. ",
+			"field.required": "Field is required. ",
+		}).
+		Add("ru", map[string]string{
+			"user.not_found": "Пользователь не найден. ",
+			"field.required": "Поле обязательно. ",
+		}).
+		SetFallback("kk", "ru")
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantMessage string
+		wantField   string
+	}{
+		{
+			name:        "Case 1",
+			ctx:         context.Background(),
+			wantMessage: "User not found. ",
+			wantField:   "Field is required. ",
+		},
+		{
+			name:        "Case 2",
+			ctx:         messages.WithLocales(context.Background(), "ru"),
+			wantMessage: "Пользователь не найден. ",
+			wantField:   "Поле обязательно. ",
+		},
+		{
+			name:        "Case 3",
+			ctx:         messages.WithAcceptLanguage(context.Background(), "kk-KZ, en;q=0.5"),
+			wantMessage: "Пользователь не найден. ",
+			wantField:   "Поле обязательно. ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i = New(&Store{
+				ID: "T-000001",
+				Message: new(messages.LocalizedMessage).
+					Bundle(bundle).
+					Key("user.not_found"),
+				Details: new(details.Details).
+					SetField(
+						new(details.FieldKey).Add("name"),
+						new(messages.LocalizedMessage).
+							Bundle(bundle).
+							Key("field.required"),
+					),
+			})
+
+			i.WithContext(tt.ctx)
+
+			if got := i.Message(); got != tt.wantMessage {
+				t.Errorf("Message() = %v, want %v", got, tt.wantMessage)
+			}
+
+			if got := i.Details().PeekFieldMessage("name").String(); got != tt.wantField {
+				t.Errorf("PeekFieldMessage() = %v, want %v", got, tt.wantField)
+			}
+		})
+	}
+}
+
+func Test_Internal_SetLocales_Shared(t *testing.T) {
+	var (
+		bundle = messages.NewBundle("en").
+			Add("en", map[string]string{"field.required": "Field is required. "}).
+			Add("ru", map[string]string{"field.required": "Поле обязательно. "})
+		shared = new(messages.LocalizedMessage).Bundle(bundle).Key("field.required")
+		key    = new(details.FieldKey).Add("name")
+		ru     = New(&Store{ID: "T-000001", Details: new(details.Details).SetField(key, shared)})
+		en     = New(&Store{ID: "T-000001", Details: new(details.Details).SetField(key, shared)})
+		done   = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		for n := 0; n < 100; n++ {
+			_, _ = json.Marshal(en.Details())
+		}
+	}()
+
+	for n := 0; n < 100; n++ {
+		ru.SetLocales("ru")
+	}
+
+	<-done
+
+	if got := ru.Details().PeekFieldMessage("name").String(); got != "Поле обязательно. " {
+		t.Errorf("PeekFieldMessage() = %v, want %v", got, "Поле обязательно. ")
+	}
+
+	if got := en.Details().PeekFieldMessage("name").String(); got != "Field is required. " {
+		t.Errorf("PeekFieldMessage() of other error = %v, want %v", got, "Field is required. ")
+	}
+
+	if got := shared.String(); got != "Field is required. " {
+		t.Errorf("String() of shared message = %v, want %v", got, "Field is required. ")
+	}
+}
+
+func Test_Internal_WithContext_Plural(t *testing.T) {
+	var bundle = messages.NewBundle("en").
+		Add("en", map[string]string{
+			"fields.invalid#one":    "{count} field is invalid",
+			"fields.invalid#other":  "{count} fields are invalid",
+			"field.too_short#one":   "Must be at least {count} character",
+			"field.too_short#other": "Must be at least {count} characters",
+		}).
+		Add("ru", map[string]string{
+			"fields.invalid#one":    "{count} поле заполнено неверно",
+			"fields.invalid#few":    "{count} поля заполнены неверно",
+			"fields.invalid#many":   "{count} полей заполнено неверно",
+			"field.too_short#one":   "Не менее {count} символа",
+			"field.too_short#few":   "Не менее {count} символов",
+			"field.too_short#many":  "Не менее {count} символов",
+			"field.too_short#other": "Не менее {count} символа",
+		})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		count       int
+		wantMessage string
+		wantField   string
+	}{
+		{
+			name:        "Case 1",
+			ctx:         context.Background(),
+			count:       1,
+			wantMessage: "1 field is invalid",
+			wantField:   "Must be at least 3 characters",
+		},
+		{
+			name:        "Case 2",
+			ctx:         messages.WithLocales(context.Background(), "ru"),
+			count:       3,
+			wantMessage: "3 поля заполнены неверно",
+			wantField:   "Не менее 3 символов",
+		},
+		{
+			name:        "Case 3",
+			ctx:         messages.WithLocales(context.Background(), "ru"),
+			count:       25,
+			wantMessage: "25 полей заполнено неверно",
+			wantField:   "Не менее 3 символов",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i = New(&Store{
+				ID: "T-000001",
+				Message: new(messages.LocalizedMessage).
+					Bundle(bundle).
+					Key("fields.invalid"),
+				Details: new(details.Details).
+					SetField(
+						new(details.FieldKey).Add("name"),
+						new(messages.LocalizedMessage).
+							Bundle(bundle).
+							Key("field.too_short").
+							Count(3),
+					),
+			})
+
+			i.SetMessageArgs(map[string]any{
+				messages.PluralCountArg: tt.count,
+			})
+			i.WithContext(tt.ctx)
+
+			if got := i.Message(); got != tt.wantMessage {
+				t.Errorf("Message() = %v, want %v", got, tt.wantMessage)
+			}
+
+			if got := i.Details().PeekFieldMessage("name").String(); got != tt.wantField {
+				t.Errorf("PeekFieldMessage() = %v, want %v", got, tt.wantField)
+			}
+		})
+	}
+}
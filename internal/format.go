@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format - форматирование ошибки для пакета fmt.
+//
+//	%s, %v - строковое представление ошибки, см. String;
+//	%q     - сообщение ошибки в кавычках;
+//	%+v    - сообщение ошибки, цепочка исходных ошибок и стек вызовов.
+func (i *Internal) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		{
+			if f.Flag('+') {
+				i.formatVerbose(f)
+				return
+			}
+
+			_, _ = io.WriteString(f, i.String())
+		}
+	case 's':
+		{
+			_, _ = io.WriteString(f, i.String())
+		}
+	case 'q':
+		{
+			_, _ = fmt.Fprintf(f, "%q", i.message())
+		}
+	default:
+		{
+			_, _ = fmt.Fprintf(f, "%%!%c(%s)", verb, i.String())
+		}
+	}
+
+	return
+}
+
+// formatVerbose - подробное форматирование ошибки.
+func (i *Internal) formatVerbose(w io.Writer) {
+	_, _ = io.WriteString(w, i.message())
+
+	formatCauses(w, i.Store.Err)
+
+	if frames := i.Store.Stack.Frames(); len(frames) > 0 {
+		_, _ = io.WriteString(w, "\nstack trace:")
+
+		for _, frame := range frames {
+			_, _ = fmt.Fprintf(w, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	}
+
+	return
+}
+
+// message - получение текста сообщения ошибки с учетом его отсутствия.
+func (i *Internal) message() (m string) {
+	if i.Store.Message != nil {
+		m = i.Store.Message.String()
+	}
+
+	return
+}
+
+// formatCauses - форматирование цепочки исходных ошибок.
+func formatCauses(w io.Writer, err error) {
+	for err != nil {
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range multi.Unwrap() {
+				formatCauses(w, e)
+			}
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "\ncaused by: %s", err.Error())
+
+		err = errors.Unwrap(err)
+	}
+
+	return
+}
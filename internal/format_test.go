@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+func Test_Internal_Format(t *testing.T) {
+	var cause = fmt.Errorf("wrapped: %w", errors.New("Test. "))
+
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		want   string
+	}{
+		{
+			name:   "Case 1",
+			format: "%v",
+			err:    nil,
+			want:   "Test error. ",
+		},
+		{
+			name:   "Case 2",
+			format: "%s",
+			err:    cause,
+			want:   "Test error. : 'wrapped: Test. '. ",
+		},
+		{
+			name:   "Case 3",
+			format: "%q",
+			err:    cause,
+			want:   "\"Test error. \"",
+		},
+		{
+			name:   "Case 4",
+			format: "%+v",
+			err:    nil,
+			want:   "Test error. ",
+		},
+		{
+			name:   "Case 5",
+			format: "%+v",
+			err:    cause,
+			want:   "Test error. \ncaused by: wrapped: Test. \ncaused by: Test. ",
+		},
+		{
+			name:   "Case 6",
+			format: "%+v",
+			err:    errors.Join(errors.New("First. "), errors.New("Second. ")),
+			want:   "Test error. \ncaused by: First. \ncaused by: Second. ",
+		},
+		{
+			name:   "Case 7",
+			format: "%d",
+			err:    nil,
+			want:   "%!d(Test error. )",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i = New(&Store{
+				ID:     "T-000001",
+				Type:   types.TypeSystem,
+				Status: types.StatusFatal,
+
+				Err: tt.err,
+				Message: new(messages.TextMessage).
+					Text("Test error. "),
+			})
+
+			if got := fmt.Sprintf(tt.format, i); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Internal_Format_StackTrace(t *testing.T) {
+	var i = New(&Store{
+		ID:        "T-000001",
+		StackMode: types.StackModeFull,
+		Message: new(messages.TextMessage).
+			Text("Test error. "),
+	})
+
+	i.Store.CaptureStack(0)
+
+	var got = fmt.Sprintf("%+v", i)
+
+	if !strings.HasPrefix(got, "Test error. \nstack trace:\n") {
+		t.Errorf("Format() = %q, want stack trace header", got)
+	}
+
+	if !strings.Contains(got, "Test_Internal_Format_StackTrace") || !strings.Contains(got, "format_test.go:") {
+		t.Errorf("Format() = %q, want test function frame", got)
+	}
+}
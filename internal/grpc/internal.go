@@ -19,3 +19,17 @@ func New(store *internal.Store) (i *Internal) {
 
 	return
 }
+
+// StatusCode - получение статус кода grpc ошибки.
+// Если статус код не задан, используется статус код по умолчанию для типа ошибки.
+func (i *Internal) StatusCode() (c int) {
+	if others := i.Internal.Store.Others; others != nil && others.Grpc != nil {
+		c = others.Grpc.StatusCode
+		return
+	}
+
+	info, _ := i.Internal.Type().Info()
+	c = info.GrpcStatusCode
+
+	return
+}
@@ -2,7 +2,9 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sm-errors/entities/details"
 	"sm-errors/types"
 )
 
@@ -25,6 +27,12 @@ type (
 		Message types.Message
 		Details types.Details
 
+		// Schema - схема деталей, проверяется при упаковке ошибки.
+		Schema *details.Schema
+
+		Stack     types.StackTrace
+		StackMode types.StackMode
+
 		Others *StoreOthers
 	}
 
@@ -32,6 +40,7 @@ type (
 	StoreOthers struct {
 		RestAPI   *RestAPIStore
 		WebSocket *WebSocketStore
+		Grpc      *GrpcStore
 	}
 
 	// RestAPIStore - хранилище для построения ошибок rest api.
@@ -43,8 +52,64 @@ type (
 	WebSocketStore struct {
 		StatusCode int
 	}
+
+	// GrpcStore - хранилище для построения ошибок grpc.
+	GrpcStore struct {
+		StatusCode int
+	}
 )
 
+// Clone - копирование хранилища.
+// Сообщение, детали и хранилища транспортов копируются, исходная ошибка и схема деталей переносятся как есть.
+func (s *Store) Clone() (s_ *Store) {
+	s_ = &Store{
+		ID:     s.ID,
+		Type:   s.Type,
+		Status: s.Status,
+
+		Err:    s.Err,
+		Schema: s.Schema,
+
+		StackMode: s.StackMode,
+	}
+
+	if s.Stack != nil {
+		s_.Stack = append(make(types.StackTrace, 0, len(s.Stack)), s.Stack...)
+	}
+
+	if s.Message != nil {
+		s_.Message = s.Message.Clone()
+	}
+
+	if s.Details != nil {
+		s_.Details = s.Details.Clone()
+	}
+
+	if s.Others != nil {
+		s_.Others = new(StoreOthers)
+
+		if s.Others.RestAPI != nil {
+			s_.Others.RestAPI = &RestAPIStore{
+				StatusCode: s.Others.RestAPI.StatusCode,
+			}
+		}
+
+		if s.Others.WebSocket != nil {
+			s_.Others.WebSocket = &WebSocketStore{
+				StatusCode: s.Others.WebSocket.StatusCode,
+			}
+		}
+
+		if s.Others.Grpc != nil {
+			s_.Others.Grpc = &GrpcStore{
+				StatusCode: s.Others.Grpc.StatusCode,
+			}
+		}
+	}
+
+	return
+}
+
 // New - создание внутренней реализации ошибки.
 func New(store *Store) (i *Internal) {
 	i = &Internal{
@@ -65,7 +130,7 @@ func (i *Internal) ID() (id types.ID) {
 func (i *Internal) Type() (t types.ErrorType) {
 	t = i.Store.Type
 
-	if t < types.TypeUnknown || t > types.TypeSystem {
+	if _, ok := t.Info(); !ok {
 		t = types.TypeUnknown
 	}
 
@@ -76,7 +141,7 @@ func (i *Internal) Type() (t types.ErrorType) {
 func (i *Internal) Status() (s types.Status) {
 	s = i.Store.Status
 
-	if s < types.StatusUnknown || s > types.StatusFatal {
+	if _, ok := s.Info(); !ok {
 		s = types.StatusUnknown
 		return
 	}
@@ -123,8 +188,14 @@ func (i *Internal) String() (s string) {
 }
 
 // SetError - установить значение исходной ошибки.
+// Если стек вызовов еще не захвачен, он захватывается в месте оборачивания.
 func (i *Internal) SetError(err error) {
 	i.Store.Err = err
+
+	if i.Store.Stack == nil {
+		i.Store.CaptureStack(1)
+	}
+
 	return
 }
 
@@ -133,3 +204,50 @@ func (i *Internal) SetMessage(m types.Message) {
 	i.Store.Message = m
 	return
 }
+
+// SetMessageArgs - установить значения именованных аргументов сообщения ошибки.
+// Для сообщений без аргументов значения игнорируются.
+func (i *Internal) SetMessageArgs(args map[string]any) {
+	if m, ok := i.Store.Message.(types.ArgsMessage); ok {
+		for name, v := range args {
+			m.SetArg(name, v)
+		}
+	}
+
+	return
+}
+
+// JoinErrors - добавить исходные ошибки к уже установленной.
+// Ошибки объединяются через errors.Join, цепочка остается доступной для errors.Is и errors.As.
+func (i *Internal) JoinErrors(errs ...error) {
+	if i.Store.Err != nil {
+		errs = append([]error{i.Store.Err}, errs...)
+	}
+
+	i.Store.Err = errors.Join(errs...)
+
+	if i.Store.Stack == nil {
+		i.Store.CaptureStack(1)
+	}
+
+	return
+}
+
+// Unwrap - получение исходной ошибки для errors.Unwrap, errors.Is и errors.As.
+func (i *Internal) Unwrap() (err error) {
+	err = i.Store.Err
+	return
+}
+
+// Is - проверка соответствия ошибки цели для errors.Is.
+// Ошибки считаются равными, если у них совпадает непустой идентификатор.
+func (i *Internal) Is(target error) (ok bool) {
+	var t, isIdentified = target.(interface{ ID() types.ID })
+
+	if !isIdentified || i.Store.ID == "" {
+		return
+	}
+
+	ok = t.ID() == i.Store.ID
+	return
+}
@@ -1058,3 +1058,192 @@ func Test_Internal_Type(t *testing.T) {
 		})
 	}
 }
+
+func Test_Internal_Unwrap(t *testing.T) {
+	var (
+		cause  = errors.New("Cause. ")
+		second = errors.New("Second cause. ")
+	)
+
+	tests := []struct {
+		name    string
+		err     error
+		join    []error
+		target  error
+		wantErr bool
+		wantIs  bool
+	}{
+		{
+			name:    "Case 1",
+			err:     nil,
+			target:  cause,
+			wantErr: false,
+			wantIs:  false,
+		},
+		{
+			name:    "Case 2",
+			err:     cause,
+			target:  cause,
+			wantErr: true,
+			wantIs:  true,
+		},
+		{
+			name:    "Case 3",
+			err:     cause,
+			join:    []error{second},
+			target:  second,
+			wantErr: true,
+			wantIs:  true,
+		},
+		{
+			name:    "Case 4",
+			err:     nil,
+			join:    []error{cause, second},
+			target:  cause,
+			wantErr: true,
+			wantIs:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(&Store{
+				ID:     "T-000001",
+				Type:   types.TypeSystem,
+				Status: types.StatusFatal,
+
+				Err: tt.err,
+				Message: new(messages.TextMessage).
+					Text("Test error. "),
+				Details: new(details.Details),
+			})
+
+			if len(tt.join) > 0 {
+				i.JoinErrors(tt.join...)
+			}
+
+			if gotErr := errors.Unwrap(i); (gotErr != nil) != tt.wantErr {
+				t.Errorf("Unwrap() = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+
+			if gotIs := errors.Is(i, tt.target); gotIs != tt.wantIs {
+				t.Errorf("errors.Is() = %v, want %v", gotIs, tt.wantIs)
+			}
+		})
+	}
+}
+
+func Test_Internal_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     types.ID
+		target error
+		want   bool
+	}{
+		{
+			name: "Case 1",
+			id:   "T-000001",
+			target: New(&Store{
+				ID: "T-000001",
+			}),
+			want: true,
+		},
+		{
+			name: "Case 2",
+			id:   "T-000001",
+			target: New(&Store{
+				ID: "T-000002",
+			}),
+			want: false,
+		},
+		{
+			name: "Case 3",
+			id:   "",
+			target: New(&Store{
+				ID: "",
+			}),
+			want: false,
+		},
+		{
+			name:   "Case 4",
+			id:     "T-000001",
+			target: errors.New("T-000001"),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(&Store{
+				ID: tt.id,
+			})
+
+			if got := i.Is(tt.target); got != tt.want {
+				t.Errorf("Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Store_Clone(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *Store
+	}{
+		{
+			name: "Case 1",
+			store: &Store{
+				ID:     "T-000001",
+				Type:   types.TypeSystem,
+				Status: types.StatusFatal,
+			},
+		},
+		{
+			name: "Case 2",
+			store: &Store{
+				ID:     "T-000002",
+				Type:   types.TypeSystem,
+				Status: types.StatusFatal,
+
+				Err: errors.New("Test. "),
+				Message: new(messages.TextMessage).
+					Text("Test error. "),
+				Details: new(details.Details).
+					Set("key", "value"),
+
+				Others: &StoreOthers{
+					RestAPI: &RestAPIStore{
+						StatusCode: 500,
+					},
+					WebSocket: &WebSocketStore{
+						StatusCode: 1011,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got = tt.store.Clone()
+
+			if !reflect.DeepEqual(got, tt.store) {
+				t.Errorf("Clone() = %v, want %v", got, tt.store)
+			}
+
+			if got.Others != nil && (got.Others == tt.store.Others ||
+				got.Others.RestAPI == tt.store.Others.RestAPI ||
+				got.Others.WebSocket == tt.store.Others.WebSocket) {
+				t.Errorf("Clone() shares others with source")
+			}
+
+			if got.Details != nil {
+				got.Details.Set("key", "changed")
+
+				if v := tt.store.Details.Peek("key"); v != "value" {
+					t.Errorf("Clone() shares details with source, got %v", v)
+				}
+			}
+		})
+	}
+}
@@ -21,7 +21,15 @@ func New(store *internal.Store) (i *Internal) {
 }
 
 // StatusCode - получение статус кода http rest api ошибки.
+// Если статус код не задан, используется статус код по умолчанию для типа ошибки.
 func (i *Internal) StatusCode() (c int) {
-	c = i.Internal.Store.Others.RestAPI.StatusCode
+	if others := i.Internal.Store.Others; others != nil && others.RestAPI != nil {
+		c = others.RestAPI.StatusCode
+		return
+	}
+
+	info, _ := i.Internal.Type().Info()
+	c = info.RestAPIStatusCode
+
 	return
 }
@@ -236,3 +236,51 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestInternal_StatusCode_Default(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *internal.Store
+		wantC int
+	}{
+		{
+			name: "Case 1",
+			store: &internal.Store{
+				ID:   "T-000001",
+				Type: types.TypeSystem,
+
+				Others: &internal.StoreOthers{
+					RestAPI: &internal.RestAPIStore{
+						StatusCode: 499,
+					},
+				},
+			},
+			wantC: 499,
+		},
+		{
+			name: "Case 2",
+			store: &internal.Store{
+				ID:   "T-000002",
+				Type: types.TypeSystem,
+			},
+			wantC: 500,
+		},
+		{
+			name: "Case 3",
+			store: &internal.Store{
+				ID:     "T-000003",
+				Type:   types.TypeNotFound,
+				Others: new(internal.StoreOthers),
+			},
+			wantC: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotC := New(tt.store).StatusCode(); gotC != tt.wantC {
+				t.Errorf("StatusCode() = %v, want %v", gotC, tt.wantC)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"encoding/xml"
@@ -23,32 +24,41 @@ type (
 )
 
 // MarshalJSON - упаковать в формат JSON.
+// Данные контекста переносятся в детали повторно, см. RegisterContextExtractors.
+// Детали проверяются по схеме, нарушения передаются обработчику, см. details.SetSchemaHandler.
 func (i *Internal) MarshalJSON() ([]byte, error) {
+	i.extractContext()
+	i.checkSchema()
+
 	var w = &wrapper{
 		ID:     i.Store.ID,
 		Type:   i.Store.Type.String(),
 		Status: i.Store.Status.String(),
 
-		Message: i.Store.Message,
 		Details: i.Store.Details,
 	}
 
 	// Сообщение
 	{
-		if _, ok := w.Message.(json.Marshaler); !ok {
-			if v, ok := w.Message.(fmt.Stringer); ok {
-				w.Message = v.String()
-			} else {
-				w.Message = nil
-			}
+		var data, err = messages.EncodeMessage(i.Store.Message)
+
+		if err != nil {
+			return nil, err
 		}
+
+		w.Message = json.RawMessage(data)
 	}
 
 	return json.Marshal(w)
 }
 
 // MarshalXML - упаковать в формат XML.
+// Данные контекста переносятся в детали повторно, см. RegisterContextExtractors.
+// Детали проверяются по схеме, нарушения передаются обработчику, см. details.SetSchemaHandler.
 func (i *Internal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	i.extractContext()
+	i.checkSchema()
+
 	var w = &wrapper{
 		ID:     i.Store.ID,
 		Type:   i.Store.Type.String(),
@@ -60,7 +70,7 @@ func (i *Internal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 	// Сообщение
 	{
-		if _, ok := w.Message.(json.Marshaler); !ok {
+		if _, ok := w.Message.(xml.Marshaler); !ok {
 			if v, ok := w.Message.(fmt.Stringer); ok {
 				w.Message = v.String()
 			} else {
@@ -78,14 +88,37 @@ func (i *Internal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.EncodeElement(w, start)
 }
 
+// checkSchema - проверка деталей ошибки по схеме с передачей нарушений обработчику.
+func (i *Internal) checkSchema() {
+	if i.Store.Schema == nil {
+		return
+	}
+
+	if err := i.Store.Schema.Check(i.Store.Details); err != nil {
+		details.ReportSchemaViolation(fmt.Errorf("error '%s': %w", i.Store.ID, err))
+	}
+
+	return
+}
+
 // UnmarshalJSON - распаковать из формата JSON.
 func (i *Internal) UnmarshalJSON(bytes []byte) (err error) {
-	var w = make(map[string]any)
+	var (
+		w   = make(map[string]any)
+		raw struct {
+			Message json.RawMessage `json:"message"`
+			Details json.RawMessage `json:"details"`
+		}
+	)
 
 	if err = json.Unmarshal(bytes, &w); err != nil {
 		return
 	}
 
+	if err = json.Unmarshal(bytes, &raw); err != nil {
+		return
+	}
+
 	i.ctx = context.Background()
 
 	// Основные данные
@@ -123,11 +156,8 @@ func (i *Internal) UnmarshalJSON(bytes []byte) (err error) {
 
 	// Сообщение
 	{
-		switch v := w["message"].(type) {
-		case string:
-			{
-				i.Store.Message = new(messages.TextMessage).Text(v)
-			}
+		if i.Store.Message, err = messages.DecodeMessage(raw.Message); err != nil {
+			return
 		}
 	}
 
@@ -135,34 +165,197 @@ func (i *Internal) UnmarshalJSON(bytes []byte) (err error) {
 	{
 		i.Store.Details = new(details.Details)
 
-		if data, ok := w["details"].(map[string]any); ok {
-			for k, v := range data {
-				if k == "fields" {
-					if data, ok := v.(map[string]any); ok {
-						for k, v := range data {
-							var m types.Message
-
-							// Сообщение
-							{
-								switch q := v.(type) {
-								case string:
-									{
-										m = new(messages.TextMessage).Text(q)
-									}
-								}
-							}
-
-							i.Store.Details.SetField(new(details.FieldKey).Add(k), m)
+		// Ключи хранилища, поля и нарушения распаковываются в порядке записи, детали, не являющиеся объектом,
+		// пропускаются. Члены "fields" и "violations" другого вида распаковываются как значения хранилища:
+		// так их передавали версии до экранирования зарезервированных ключей.
+		err = decodeJSONObject(raw.Details, func(k string, v json.RawMessage) (err error) {
+			switch {
+			case k == details.KeyFields && isJSONObject(v):
+				return i.decodeFieldsJSON(v)
+			case k == details.KeyViolations && isViolationsJSON(v):
+				return i.decodeViolationsJSON(v)
+			}
+
+			var value any
+
+			if err = json.Unmarshal(v, &value); err != nil {
+				return
+			}
+
+			i.Store.Details.Set(details.UnescapeKey(k), value)
+
+			return
+		})
+	}
+
+	return
+}
+
+// decodeFieldsJSON - распаковать сообщения полей деталей в порядке записи.
+func (i *Internal) decodeFieldsJSON(data json.RawMessage) (err error) {
+	return decodeJSONObject(data, func(k string, v json.RawMessage) (err error) {
+		var m types.Message
+
+		if m, err = messages.DecodeMessage(v); err != nil {
+			return
+		}
+
+		i.Store.Details.SetField(parseFieldKey(k), m)
+
+		return
+	})
+}
+
+// decodeViolationsJSON - распаковать нарушения полей деталей в порядке записи.
+func (i *Internal) decodeViolationsJSON(data json.RawMessage) (err error) {
+	return decodeJSONObject(data, func(k string, v json.RawMessage) (err error) {
+		var list []struct {
+			Code    string          `json:"code"`
+			Params  map[string]any  `json:"params"`
+			Message json.RawMessage `json:"message"`
+		}
+
+		if err = json.Unmarshal(v, &list); err != nil {
+			return
+		}
+
+		var key = parseFieldKey(k)
+
+		for _, violation := range list {
+			var m types.Message
+
+			if m, err = messages.DecodeMessage(violation.Message); err != nil {
+				return
+			}
+
+			i.Store.Details.AddFieldViolation(key, types.DetailsFieldViolation{
+				Code:    violation.Code,
+				Params:  violation.Params,
+				Message: m,
+			})
+		}
+
+		return
+	})
+}
+
+// isJSONObject - проверка, является ли значение JSON объектом.
+func isJSONObject(data json.RawMessage) (ok bool) {
+	data = bytes.TrimSpace(data)
+
+	return len(data) > 0 && data[0] == '{'
+}
+
+// isViolationsJSON - проверка, является ли значение JSON нарушениями полей: объектом со списками.
+func isViolationsJSON(data json.RawMessage) (ok bool) {
+	var w map[string][]json.RawMessage
+
+	return isJSONObject(data) && json.Unmarshal(data, &w) == nil
+}
+
+// decodeJSONObject - обход членов объекта JSON в порядке записи.
+// Значения, не являющиеся объектом, пропускаются.
+func decodeJSONObject(data json.RawMessage, fn func(k string, v json.RawMessage) (err error)) (err error) {
+	if data = bytes.TrimSpace(data); len(data) == 0 || data[0] != '{' {
+		return
+	}
+
+	var (
+		decoder = json.NewDecoder(bytes.NewReader(data))
+		token   json.Token
+	)
+
+	if _, err = decoder.Token(); err != nil {
+		return
+	}
+
+	for decoder.More() {
+		if token, err = decoder.Token(); err != nil {
+			return
+		}
+
+		var v json.RawMessage
+
+		if err = decoder.Decode(&v); err != nil {
+			return
+		}
+
+		if err = fn(token.(string), v); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalXML - распаковать из формата XML.
+// Распаковывается документ <Error id type status><Message/><Details/></Error>, см. MarshalXML.
+func (i *Internal) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	i.ctx = context.Background()
+
+	// Основные данные
+	{
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "id":
+				i.Store.ID = types.ID(attr.Value)
+			case "type":
+				i.Store.Type = types.ParseErrorType(attr.Value)
+			case "status":
+				i.Store.Status = types.ParseStatus(attr.Value)
+			}
+		}
+	}
+
+	i.Store.Message = nil
+	i.Store.Details = new(details.Details)
+
+	for {
+		var token xml.Token
+
+		if token, err = d.Token(); err != nil {
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			{
+				switch t.Name.Local {
+				case "Message":
+					{
+						if i.Store.Message, err = messages.DecodeMessageXML(d, t); err != nil {
+							return
+						}
+					}
+				case "Details":
+					{
+						if err = d.DecodeElement(i.Store.Details, &t); err != nil {
+							return
+						}
+					}
+				default:
+					{
+						if err = d.Skip(); err != nil {
+							return
 						}
 					}
-
-					continue
 				}
-
-				i.Store.Details.Set(k, v)
 			}
+		case xml.EndElement:
+			return
 		}
 	}
+}
+
+// parseFieldKey - разбор ключа поля при распаковке.
+// Запись ключа определяется автоматически: через точку, JSON Pointer или JSONPath,
+// ключ, который не удалось разобрать, сохраняется как имя поля.
+func parseFieldKey(k string) (key types.DetailsFieldKey) {
+	var err error
+
+	if key, err = details.ParseFieldKeyNotation(k, details.FieldKeyNotationDefault); err != nil {
+		key = new(details.FieldKey).Add(k)
+	}
 
 	return
 }
@@ -504,3 +504,581 @@ func Test_Internal_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func Test_Internal_JSON_ErrorTypeRoundTrip(t *testing.T) {
+	var custom, err = types.RegisterErrorType(types.ErrorTypeInfo{
+		Name: "test_round_trip",
+	})
+
+	if err != nil {
+		custom = types.ParseErrorType("test_round_trip")
+	}
+
+	tests := []struct {
+		name  string
+		t     types.ErrorType
+		wantT types.ErrorType
+	}{
+		{
+			name:  "Case 1",
+			t:     types.TypeConflict,
+			wantT: types.TypeConflict,
+		},
+		{
+			name:  "Case 2",
+			t:     custom,
+			wantT: custom,
+		},
+		{
+			name:  "Case 3",
+			t:     1000,
+			wantT: types.TypeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i = New(&Store{
+				ID:   "T-000001",
+				Type: tt.t,
+				Message: new(messages.TextMessage).
+					Text("Message. "),
+				Details: new(details.Details),
+			})
+
+			data, err := json.Marshal(i)
+
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+
+			var got = New(new(Store))
+
+			if err = json.Unmarshal(data, got); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+
+			if got.Type() != tt.wantT {
+				t.Errorf("Type() = %v, want %v", got.Type(), tt.wantT)
+			}
+		})
+	}
+}
+
+func Test_Internal_JSON_StatusRoundTrip(t *testing.T) {
+	var custom, err = types.RegisterStatus(types.StatusInfo{
+		Name:           "test_warning",
+		Severity:       50,
+		SyslogSeverity: types.SyslogWarning,
+	})
+
+	if err != nil {
+		custom = types.ParseStatus("test_warning")
+	}
+
+	tests := []struct {
+		name  string
+		s     types.Status
+		wantS types.Status
+	}{
+		{
+			name:  "Case 1",
+			s:     types.StatusError,
+			wantS: types.StatusError,
+		},
+		{
+			name:  "Case 2",
+			s:     custom,
+			wantS: custom,
+		},
+		{
+			name:  "Case 3",
+			s:     1000,
+			wantS: types.StatusUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i = New(&Store{
+				ID:     "T-000001",
+				Status: tt.s,
+				Message: new(messages.TextMessage).
+					Text("Message. "),
+				Details: new(details.Details),
+			})
+
+			data, err := json.Marshal(i)
+
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+
+			var got = New(new(Store))
+
+			if err = json.Unmarshal(data, got); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+
+			if got.Status() != tt.wantS {
+				t.Errorf("Status() = %v, want %v", got.Status(), tt.wantS)
+			}
+		})
+	}
+}
+
+func Test_Internal_JSON_MessageKinds(t *testing.T) {
+	var i = New(&Store{
+		ID:     "T-000001",
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TemplateMessage).
+			Key("T-000001").
+			Template("User {user_id} not found").
+			Arg("user_id", "u-1"),
+		Details: new(details.Details).
+			SetField(
+				new(details.FieldKey).Add("name"),
+				new(messages.PluralMessage).
+					Locale("ru").
+					Form(messages.PluralFew, "Не менее {count} символов").
+					Form(messages.PluralOther, "Не менее {count} символа").
+					Count(3),
+			),
+	})
+
+	var data, err = json.Marshal(i)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var i_ = New(new(Store))
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := i_.Store.Message.(*messages.TemplateMessage); !ok {
+		t.Errorf("UnmarshalJSON() message = %T, want *messages.TemplateMessage", i_.Store.Message)
+	}
+
+	if got := i_.Message(); got != "User u-1 not found" {
+		t.Errorf("Message() = %v, want %v", got, "User u-1 not found")
+	}
+
+	var m = i_.Details().PeekFieldMessage("name")
+
+	if _, ok := m.(*messages.PluralMessage); !ok {
+		t.Errorf("UnmarshalJSON() field message = %T, want *messages.PluralMessage", m)
+	}
+
+	if got := m.String(); got != "Не менее 3 символов" {
+		t.Errorf("PeekFieldMessage() = %v, want %v", got, "Не менее 3 символов")
+	}
+
+	// Неизвестные типы заменяются итоговым текстом.
+	data = []byte(`{"id":"T-000001","type":"validation","status":"error",` +
+		`"message":{"kind":"custom","payload":{"a":1},"text":"Custom text"},` +
+		`"details":{"fields":{"name":{"kind":"custom","text":"Field text"}}}}`)
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := i_.Message(); got != "Custom text" {
+		t.Errorf("Message() = %v, want %v", got, "Custom text")
+	}
+
+	if got := i_.Details().PeekFieldMessage("name").String(); got != "Field text" {
+		t.Errorf("PeekFieldMessage() = %v, want %v", got, "Field text")
+	}
+}
+
+func Test_Internal_JSON_FieldKeys(t *testing.T) {
+	var key = new(details.FieldKey).
+		Add("user").
+		AddArray("items", 3).
+		AddMap("labels", "a.b").
+		Add("name")
+
+	var i = New(&Store{
+		ID:     "T-000001",
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).Text("Message. "),
+		Details: new(details.Details).SetField(key, new(messages.TextMessage).Text("Field. ")),
+	})
+
+	var data, err = json.Marshal(i)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var i_ = New(new(Store))
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	var fields = i_.Details().Fields()
+
+	if len(fields) != 1 {
+		t.Fatalf("UnmarshalJSON() fields = %v, want 1 field", fields)
+	}
+
+	if !key.(*details.FieldKey).Equal(fields[0].Key) {
+		t.Errorf("UnmarshalJSON() key = %#v, want %#v", fields[0].Key, key)
+	}
+
+	if got, ok := fields[0].Key.(*details.FieldKey); !ok || len(got.Segments()) != 6 {
+		t.Errorf("UnmarshalJSON() key segments = %#v", fields[0].Key)
+	}
+
+	// Ключи в записи JSON Pointer и JSONPath распознаются автоматически.
+	for _, k := range []string{"/user/items/3/name", "$.user.items[3].name"} {
+		data = []byte(`{"id":"T-000001","type":"validation","status":"error","message":"Message. ",` +
+			`"details":{"fields":{"` + k + `":"Field. "}}}`)
+
+		if err = json.Unmarshal(data, i_); err != nil {
+			t.Fatal(err)
+		}
+
+		if m := i_.Details().PeekFieldMessage("user.items[3].name"); m == nil {
+			t.Errorf("UnmarshalJSON() field '%s' is not found", k)
+		}
+	}
+}
+
+func Test_Internal_JSON_Violations(t *testing.T) {
+	var i = New(&Store{
+		ID:     "T-000001",
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).Text("Message. "),
+		Details: new(details.Details).
+			AddFieldViolation(new(details.FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "min_length",
+				Params:  map[string]any{"min": 8},
+				Message: new(messages.TextMessage).Text("Too short"),
+			}).
+			AddFieldViolation(new(details.FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "pattern",
+				Message: new(messages.TextMessage).Text("No digit"),
+			}),
+	})
+
+	var data, err = json.Marshal(i)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var i_ = New(new(Store))
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	var want = []types.DetailsFieldViolation{
+		{
+			Code:    "min_length",
+			Params:  map[string]any{"min": float64(8)},
+			Message: new(messages.TextMessage).Text("Too short"),
+		},
+		{
+			Code:    "pattern",
+			Message: new(messages.TextMessage).Text("No digit"),
+		},
+	}
+
+	if got := i_.Details().PeekFieldViolations("password"); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalJSON() violations = %v, want %v", got, want)
+	}
+
+	if got := i_.Details().PeekFieldMessage("password"); got == nil || got.String() != "Too short" {
+		t.Errorf("UnmarshalJSON() field message = %v, want %v", got, "Too short")
+	}
+
+	if got := i_.Details().Peek("violations"); got != nil {
+		t.Errorf("UnmarshalJSON() storage violations = %v, want nil", got)
+	}
+
+	// Старый формат без нарушений распаковывается в основное сообщение поля.
+	data = []byte(`{"id":"T-000001","type":"validation","status":"error","message":"Message. ",` +
+		`"details":{"fields":{"password":"Too short"}}}`)
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := i_.Details().PeekFieldViolations("password"); got != nil {
+		t.Errorf("UnmarshalJSON() violations = %v, want nil", got)
+	}
+}
+
+func Test_Internal_JSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		order details.KeyOrder
+	}{
+		{
+			name:  "Case 1",
+			order: details.KeyOrderSorted,
+		},
+		{
+			name:  "Case 2",
+			order: details.KeyOrderInsertion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details.SetDefaultKeyOrder(tt.order)
+			defer details.SetDefaultKeyOrder(details.KeyOrderDefault)
+
+			var ds = new(details.Details).
+				Set("zeta", "z").
+				Set("alpha", float64(1)).
+				SetField(new(details.FieldKey).Add("name"), new(messages.TextMessage).Text("Required")).
+				SetField(new(details.FieldKey).Add("email"), new(messages.TextMessage).Text("Invalid")).
+				SetField(new(details.FieldKey).AddArray("items", 2), new(messages.TextMessage).Text("Empty"))
+
+			ds.AddFieldViolation(new(details.FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "min_length",
+				Message: new(messages.TextMessage).Text("Too short"),
+			})
+
+			ds.AddFieldViolation(new(details.FieldKey).Add("age"), types.DetailsFieldViolation{
+				Code:    "min",
+				Message: new(messages.TextMessage).Text("Too small"),
+			})
+
+			var i = New(&Store{
+				ID:     "T-000001",
+				Type:   types.TypeValidation,
+				Status: types.StatusError,
+
+				Message: new(messages.TextMessage).Text("Message. "),
+				Details: ds,
+			})
+
+			var data, err = json.Marshal(i)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Распаковка и упаковка повторяются, чтобы обнаружить порядок обхода карт.
+			for n := 0; n < 20; n++ {
+				var i_ = New(new(Store))
+
+				if err = json.Unmarshal(data, i_); err != nil {
+					t.Fatal(err)
+				}
+
+				var got []byte
+
+				if got, err = json.Marshal(i_); err != nil {
+					t.Fatal(err)
+				}
+
+				if string(got) != string(data) {
+					t.Fatalf("MarshalJSON() after UnmarshalJSON() =\n%s\nwant\n%s", got, data)
+				}
+			}
+		})
+	}
+}
+
+func Test_Internal_JSON_ReservedKeys(t *testing.T) {
+	var i = New(&Store{
+		ID:     "T-000001",
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).Text("Message. "),
+		Details: new(details.Details).
+			Set("fields", "user").
+			Set("violations", float64(2)).
+			AddFieldViolation(new(details.FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "min_length",
+				Message: new(messages.TextMessage).Text("Too short"),
+			}),
+	})
+
+	var data, err = json.Marshal(i)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var i_ = New(new(Store))
+
+	if err = json.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := i_.Details().Peek("fields"); got != "user" {
+		t.Errorf("UnmarshalJSON() storage fields = %v, want %v", got, "user")
+	}
+
+	if got := i_.Details().Peek("violations"); got != float64(2) {
+		t.Errorf("UnmarshalJSON() storage violations = %v, want %v", got, 2)
+	}
+
+	if got := i_.Details().PeekFieldViolations("password"); len(got) != 1 || got[0].Code != "min_length" {
+		t.Errorf("UnmarshalJSON() violations = %v, want min_length", got)
+	}
+}
+
+func Test_Internal_JSON_LegacyReservedKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantValues map[string]any
+		wantData   string
+	}{
+		{
+			name:       "Case 1",
+			data:       `{"fields":"user","violations":2}`,
+			wantValues: map[string]any{"fields": "user", "violations": float64(2)},
+			wantData:   `{"_fields":"user","_violations":2}`,
+		},
+		{
+			name:       "Case 2",
+			data:       `{"violations":{"limit":3},"fields":{"password":"Too short"}}`,
+			wantValues: map[string]any{"violations": map[string]any{"limit": float64(3)}},
+			wantData:   `{"_violations":{"limit":3},"fields":{"password":"Too short"}}`,
+		},
+		{
+			name:       "Case 3",
+			data:       `{"violations":[],"fields":{"password":"Too short"}}`,
+			wantValues: map[string]any{"violations": []any{}},
+			wantData:   `{"_violations":[],"fields":{"password":"Too short"}}`,
+		},
+		{
+			// Ключ "_fields" старых версий распаковывается как экранированный ключ "fields".
+			name:       "Case 4",
+			data:       `{"_fields":"own","fields":{"password":"Too short"}}`,
+			wantValues: map[string]any{"fields": "own", "_fields": nil},
+			wantData:   `{"_fields":"own","fields":{"password":"Too short"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				i    = New(new(Store))
+				data = `{"id":"T-000001","type":"validation","status":"error","message":"Message. ","details":` + tt.data + `}`
+			)
+
+			if err := json.Unmarshal([]byte(data), i); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+
+			for k, want := range tt.wantValues {
+				if got := i.Details().Peek(k); !reflect.DeepEqual(got, want) {
+					t.Errorf("UnmarshalJSON() storage %s = %#v, want %#v", k, got, want)
+				}
+			}
+
+			gotData, err := json.Marshal(i.Details())
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(gotData) != tt.wantData {
+				t.Errorf("MarshalJSON() = %s, want %s", gotData, tt.wantData)
+			}
+		})
+	}
+}
+
+func Test_Internal_UnmarshalXML(t *testing.T) {
+	var i = New(&Store{
+		ID:     "T-000001",
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TemplateMessage).
+			Key("user.not_found").
+			Template("User {user_id} not found").
+			Arg("user_id", 7),
+		Details: new(details.Details).
+			Set("key", "value").
+			Set("nested", map[string]any{"a": "1"}).
+			SetField(new(details.FieldKey).Add("name"), new(messages.TextMessage).Text("Required")).
+			AddFieldViolation(new(details.FieldKey).Add("password"), types.DetailsFieldViolation{
+				Code:    "min_length",
+				Params:  map[string]any{"min": 8},
+				Message: new(messages.TextMessage).Text("Too short"),
+			}),
+	})
+
+	var data, err = xml.Marshal(i)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var i_ = New(new(Store))
+
+	if err = xml.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "Case 1", got: i_.ID(), want: types.ID("T-000001")},
+		{name: "Case 2", got: i_.Type(), want: types.TypeValidation},
+		{name: "Case 3", got: i_.Status(), want: types.StatusError},
+		{name: "Case 4", got: i_.Message(), want: "User 7 not found"},
+		{name: "Case 5", got: i_.Details().Peek("key"), want: "value"},
+		{name: "Case 6", got: i_.Details().Peek("nested"), want: map[string]any{"a": "1"}},
+		{name: "Case 7", got: i_.Details().PeekFieldMessage("name").String(), want: "Required"},
+		{name: "Case 8", got: len(i_.Details().PeekFieldViolations("password")), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("UnmarshalXML() = %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := i_.Store.Message.(*messages.TemplateMessage); !ok {
+		t.Errorf("UnmarshalXML() message type = %T, want *messages.TemplateMessage", i_.Store.Message)
+	}
+
+	// Повторная упаковка совпадает с исходным документом.
+	var again []byte
+
+	if again, err = xml.Marshal(i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(data) {
+		t.Errorf("MarshalXML() after UnmarshalXML() = %s, want %s", again, data)
+	}
+
+	// Документ без деталей распаковывается с пустыми деталями.
+	data = []byte(`<Error id="T-000002" type="system" status="fatal"><Message>Message. </Message></Error>`)
+
+	if err = xml.Unmarshal(data, i_); err != nil {
+		t.Fatal(err)
+	}
+
+	if i_.ID() != "T-000002" || i_.Message() != "Message. " || i_.Details() == nil || i_.Details().Peek("key") != nil {
+		t.Errorf("UnmarshalXML() = %v", i_)
+	}
+}
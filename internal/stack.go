@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"runtime"
+	"sm-errors/types"
+	"sync/atomic"
+)
+
+// stackDepth - максимальная глубина захвата стека вызовов.
+const stackDepth = 32
+
+// stackMode - глобальный режим захвата стека вызовов.
+var stackMode = func() (m *atomic.Int64) {
+	m = new(atomic.Int64)
+	m.Store(int64(types.StackModeOff))
+
+	return
+}()
+
+// SetStackMode - установить глобальный режим захвата стека вызовов.
+// Значение types.StackModeDefault сбрасывает режим к types.StackModeOff.
+func SetStackMode(m types.StackMode) {
+	if m == types.StackModeDefault {
+		m = types.StackModeOff
+	}
+
+	stackMode.Store(int64(m))
+	return
+}
+
+// StackMode - получение глобального режима захвата стека вызовов.
+func StackMode() (m types.StackMode) {
+	m = types.StackMode(stackMode.Load())
+	return
+}
+
+// CaptureStack - захват стека вызовов.
+// skip - количество пропускаемых кадров относительно функции, вызвавшей CaptureStack.
+func (s *Store) CaptureStack(skip int) {
+	var mode = s.StackMode
+
+	if mode == types.StackModeDefault {
+		mode = StackMode()
+	}
+
+	var pcs []uintptr
+
+	switch mode {
+	case types.StackModeCaller:
+		{
+			pcs = make([]uintptr, 1)
+		}
+	case types.StackModeFull:
+		{
+			pcs = make([]uintptr, stackDepth)
+		}
+	default:
+		{
+			return
+		}
+	}
+
+	if n := runtime.Callers(skip+2, pcs); n > 0 {
+		s.Stack = pcs[:n]
+	}
+
+	return
+}
+
+// StackTrace - получение стека вызовов ошибки.
+func (i *Internal) StackTrace() (st types.StackTrace) {
+	st = i.Store.Stack
+	return
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"errors"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+func Test_Store_CaptureStack(t *testing.T) {
+	tests := []struct {
+		name       string
+		global     types.StackMode
+		mode       types.StackMode
+		wantFrames int
+	}{
+		{
+			name:       "Case 1",
+			global:     types.StackModeOff,
+			mode:       types.StackModeDefault,
+			wantFrames: 0,
+		},
+		{
+			name:       "Case 2",
+			global:     types.StackModeCaller,
+			mode:       types.StackModeDefault,
+			wantFrames: 1,
+		},
+		{
+			name:       "Case 3",
+			global:     types.StackModeFull,
+			mode:       types.StackModeOff,
+			wantFrames: 0,
+		},
+		{
+			name:       "Case 4",
+			global:     types.StackModeOff,
+			mode:       types.StackModeFull,
+			wantFrames: -1,
+		},
+	}
+
+	defer SetStackMode(types.StackModeOff)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStackMode(tt.global)
+
+			var s = &Store{
+				StackMode: tt.mode,
+			}
+
+			s.CaptureStack(0)
+
+			var frames = s.Stack.Frames()
+
+			switch {
+			case tt.wantFrames < 0:
+				{
+					if len(frames) < 2 {
+						t.Errorf("CaptureStack() frames = %d, want more than one", len(frames))
+					}
+				}
+			case len(frames) != tt.wantFrames:
+				{
+					t.Errorf("CaptureStack() frames = %d, want %d", len(frames), tt.wantFrames)
+				}
+			}
+
+			if len(frames) > 0 && !strings.HasSuffix(frames[0].Function, "Test_Store_CaptureStack.func1") {
+				t.Errorf("CaptureStack() first frame = %v, want test function", frames[0].Function)
+			}
+		})
+	}
+}
+
+func Test_Internal_SetError_CaptureStack(t *testing.T) {
+	SetStackMode(types.StackModeCaller)
+	defer SetStackMode(types.StackModeOff)
+
+	var i = New(&Store{
+		ID: "T-000001",
+		Message: new(messages.TextMessage).
+			Text("Test error. "),
+	})
+
+	i.SetError(errors.New("Test. "))
+
+	var frames = i.StackTrace().Frames()
+
+	if len(frames) != 1 || !strings.HasSuffix(frames[0].Function, "Test_Internal_SetError_CaptureStack") {
+		t.Errorf("StackTrace() = %v, want test function frame", frames)
+	}
+
+	var st = i.StackTrace()
+
+	i.JoinErrors(errors.New("Test 2. "))
+
+	if len(i.StackTrace()) != len(st) || i.StackTrace()[0] != st[0] {
+		t.Errorf("JoinErrors() replaced captured stack")
+	}
+}
+
+func TestSetStackMode(t *testing.T) {
+	defer SetStackMode(types.StackModeOff)
+
+	SetStackMode(types.StackModeFull)
+
+	if m := StackMode(); m != types.StackModeFull {
+		t.Errorf("StackMode() = %v, want %v", m, types.StackModeFull)
+	}
+
+	SetStackMode(types.StackModeDefault)
+
+	if m := StackMode(); m != types.StackModeOff {
+		t.Errorf("StackMode() = %v, want %v", m, types.StackModeOff)
+	}
+}
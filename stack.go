@@ -0,0 +1,17 @@
+package errors
+
+import (
+	"sm-errors/internal"
+	"sm-errors/types"
+)
+
+// SetStackMode - установить глобальный режим захвата стека вызовов.
+// Режим применяется к ошибкам, в конструкторе которых не указан собственный режим.
+func SetStackMode(m types.StackMode) {
+	internal.SetStackMode(m)
+}
+
+// StackMode - получение глобального режима захвата стека вызовов.
+func StackMode() (m types.StackMode) {
+	return internal.StackMode()
+}
@@ -0,0 +1,371 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"strings"
+)
+
+// Комментарии записей файлов переводов.
+const (
+	translationKeyComment   = "key: "
+	translationFieldComment = "field: "
+)
+
+type (
+	// TranslationEntry - сообщение ошибки каталога для перевода.
+	TranslationEntry struct {
+		ID    types.ID
+		Field string
+		Key   string
+
+		Source messages.Source
+	}
+
+	// TranslationReport - отчет о состоянии переводов.
+	// Untranslated - записи каталога без точного перевода, Stale - записи файла,
+	// которых больше нет в каталоге.
+	TranslationReport struct {
+		Locale string
+
+		Untranslated []*messages.POEntry
+		Stale        []*messages.POEntry
+	}
+)
+
+// Translations - получение сообщений и сообщений полей каталога для перевода.
+// Ключ перевода берется из сообщения, а если его нет - строится по идентификатору
+// ошибки, для сообщений полей - с ключом поля через двоеточие, например "U-000001:name".
+func (c *Catalog) Translations() (list []*TranslationEntry) {
+	for _, d := range c.List() {
+		if src, ok := messages.SourceOf(d.Message); ok {
+			list = append(list, newTranslationEntry(d.ID, "", src))
+		}
+
+		if d.Details == nil {
+			continue
+		}
+
+		for _, f := range d.Details.Fields() {
+			if f.Key == nil {
+				continue
+			}
+
+			if src, ok := messages.SourceOf(f.Message); ok {
+				list = append(list, newTranslationEntry(d.ID, f.Key.String(), src))
+			}
+		}
+	}
+
+	return
+}
+
+// ExportPO - получение файла переводов каталога для языка.
+// Для пустого языка создается шаблон .pot. Переводы из существующего файла
+// переносятся в записи с тем же идентификатором ошибки и исходным текстом,
+// записи, которых нет в каталоге, помечаются устаревшими.
+func (c *Catalog) ExportPO(locale string, existing *messages.POFile) (f *messages.POFile, r *TranslationReport) {
+	var categories []string
+
+	f = new(messages.POFile)
+	r = &TranslationReport{
+		Locale: messages.NormalizeLocale(locale),
+	}
+
+	// Заголовок
+	{
+		if r.Locale == "" {
+			f.SetHeader(messages.POHeaderContentType, "text/plain; charset=UTF-8")
+			f.SetHeader(messages.POHeaderPluralForms, "nplurals=INTEGER; plural=EXPRESSION;")
+		} else {
+			var list, header = messages.PluralForms(r.Locale)
+
+			for _, category := range list {
+				categories = append(categories, string(category))
+			}
+
+			f.SetHeader(messages.POHeaderLanguage, r.Locale)
+			f.SetHeader(messages.POHeaderContentType, "text/plain; charset=UTF-8")
+			f.SetHeader(messages.POHeaderPluralForms, header)
+		}
+	}
+
+	// Записи
+	{
+		var seen = make(map[[2]string]*messages.POEntry)
+
+		for _, t := range c.Translations() {
+			var id = [2]string{string(t.ID), t.Source.Text}
+
+			if e, ok := seen[id]; ok {
+				e.ExtractedComments = append(e.ExtractedComments, t.comments()...)
+				continue
+			}
+
+			var e = &messages.POEntry{
+				ExtractedComments: t.comments(),
+
+				Context:  string(t.ID),
+				ID:       t.Source.Text,
+				IDPlural: t.Source.Plural,
+			}
+
+			if e.IDPlural != "" && len(categories) > 0 {
+				e.Str = make([]string, len(categories))
+			}
+
+			if existing != nil {
+				if previous, ok := existing.Lookup(e.Context, e.ID); ok {
+					e.Comments = previous.Comments
+					e.Flags = previous.Flags
+
+					if len(previous.Str) > 0 {
+						e.Str = previous.Str
+					}
+				}
+			}
+
+			if r.Locale != "" && !e.Translated() {
+				r.Untranslated = append(r.Untranslated, e)
+			}
+
+			seen[id] = e
+			f.Entries = append(f.Entries, e)
+		}
+
+		if existing != nil {
+			for _, e := range existing.Entries {
+				if e.Obsolete {
+					f.Entries = append(f.Entries, e)
+					continue
+				}
+
+				if _, ok := seen[[2]string{e.Context, e.ID}]; ok {
+					continue
+				}
+
+				var stale = *e
+				stale.Obsolete = true
+
+				r.Stale = append(r.Stale, &stale)
+				f.Entries = append(f.Entries, &stale)
+			}
+		}
+	}
+
+	return
+}
+
+// ImportPO - загрузка переводов из файла в набор переводов.
+// Язык берется из заголовка Language файла, переводы записываются по ключам
+// сообщений каталога (см. Translations), формы множественного числа - по ключам
+// messages.PluralKey. Если среди форм языка нет messages.PluralOther, она заполняется
+// переводом формы messages.PluralOtherForm, например для дробных чисел в русском языке.
+// Неточные и пустые переводы не загружаются.
+func (c *Catalog) ImportPO(b *messages.Bundle, f *messages.POFile) (r *TranslationReport, err error) {
+	r = &TranslationReport{
+		Locale: f.Language(),
+	}
+
+	if r.Locale == "" {
+		err = fmt.Errorf("po: %s: missing %s header", f.Name, messages.POHeaderLanguage)
+		return
+	}
+
+	var (
+		errs          []error
+		seen          = make(map[[2]string]bool)
+		categories, _ = messages.PluralForms(r.Locale)
+		other         = messages.PluralOtherForm(r.Locale)
+		translations  = make(map[string]string)
+	)
+
+	for _, t := range c.Translations() {
+		var id = [2]string{string(t.ID), t.Source.Text}
+
+		var e, ok = f.Lookup(id[0], id[1])
+
+		if !ok || !e.Translated() {
+			if !seen[id] {
+				r.Untranslated = append(r.Untranslated, &messages.POEntry{
+					ExtractedComments: t.comments(),
+
+					Context:  id[0],
+					ID:       id[1],
+					IDPlural: t.Source.Plural,
+				})
+			}
+
+			seen[id] = true
+			continue
+		}
+
+		seen[id] = true
+
+		if e.IDPlural == "" {
+			translations[t.Key] = e.Str[0]
+			continue
+		}
+
+		if len(e.Str) != len(categories) {
+			errs = append(errs, fmt.Errorf("po: %s: %s %q: expected %d plural forms, got %d", f.Name, e.Context, e.ID, len(categories), len(e.Str)))
+			continue
+		}
+
+		for i, category := range categories {
+			translations[messages.PluralKey(t.Key, category)] = e.Str[i]
+
+			if category == other && other != messages.PluralOther {
+				translations[messages.PluralKey(t.Key, messages.PluralOther)] = e.Str[i]
+			}
+		}
+	}
+
+	for _, e := range f.Entries {
+		if !e.Obsolete && !seen[[2]string{e.Context, e.ID}] {
+			r.Stale = append(r.Stale, e)
+		}
+	}
+
+	b.Add(r.Locale, translations)
+
+	err = errors.Join(errs...)
+
+	return
+}
+
+// Localize - перевод сообщений и сообщений полей каталога на локализованные сообщения набора.
+// Исходные тексты добавляются в язык по умолчанию набора, если для ключа нет перевода.
+// Аргументы сообщений сохраняются, аргумент количества сообщений с формами множественного
+// числа переносится в messages.PluralCountArg.
+func (c *Catalog) Localize(b *messages.Bundle) {
+	c.rwMux.Lock()
+	defer c.rwMux.Unlock()
+
+	var (
+		defaults = make(map[string]string)
+		existing = make(map[string]bool)
+	)
+
+	for _, key := range b.Keys(b.DefaultLocale()) {
+		existing[key] = true
+	}
+
+	var localize = func(id types.ID, field string, m types.Message) types.Message {
+		var src, ok = messages.SourceOf(m)
+
+		if _, localized := m.(*messages.LocalizedMessage); !ok || localized {
+			return m
+		}
+
+		var (
+			t  = newTranslationEntry(id, field, src)
+			m_ = new(messages.LocalizedMessage).Bundle(b).Key(t.Key)
+		)
+
+		if am, ok := m.(types.ArgsMessage); ok {
+			for name, v := range am.Args() {
+				m_.SetArg(name, v)
+			}
+		}
+
+		if pm, ok := m.(*messages.PluralMessage); ok {
+			if n, ok := pm.Args()[pm.CountArg()]; ok {
+				m_.Count(n)
+			}
+		}
+
+		if src.Plural == "" {
+			if !existing[t.Key] {
+				defaults[t.Key] = src.Text
+			}
+		} else {
+			for category, text := range map[messages.PluralCategory]string{messages.PluralOne: src.Text, messages.PluralOther: src.Plural} {
+				if key := messages.PluralKey(t.Key, category); !existing[key] {
+					defaults[key] = text
+				}
+			}
+		}
+
+		return m_
+	}
+
+	for _, d := range c.definitions {
+		d.Message = localize(d.ID, "", d.Message)
+
+		if d.Details == nil {
+			continue
+		}
+
+		var fields = d.Details.Fields()
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		for i, f := range fields {
+			if f.Key != nil {
+				fields[i].Message = localize(d.ID, f.Key.String(), f.Message)
+			}
+		}
+
+		d.Details.ResetFields()
+		d.Details.SetFields(fields...)
+	}
+
+	b.Add(b.DefaultLocale(), defaults)
+}
+
+// String - получение текста отчета о переводах.
+func (r *TranslationReport) String() (str string) {
+	var buf = new(strings.Builder)
+
+	fmt.Fprintf(buf, "%s: %d untranslated, %d stale", r.Locale, len(r.Untranslated), len(r.Stale))
+
+	for _, list := range []struct {
+		name    string
+		entries []*messages.POEntry
+	}{
+		{name: "untranslated", entries: r.Untranslated},
+		{name: "stale", entries: r.Stale},
+	} {
+		for _, e := range list.entries {
+			fmt.Fprintf(buf, "\n  %s %s: %q", list.name, e.Context, e.ID)
+		}
+	}
+
+	return buf.String()
+}
+
+// newTranslationEntry - создание сообщения каталога для перевода.
+func newTranslationEntry(id types.ID, field string, src messages.Source) (t *TranslationEntry) {
+	t = &TranslationEntry{
+		ID:     id,
+		Field:  field,
+		Key:    src.Key,
+		Source: src,
+	}
+
+	if t.Key == "" {
+		t.Key = string(id)
+
+		if field != "" {
+			t.Key += ":" + field
+		}
+	}
+
+	return
+}
+
+// comments - получение комментариев записи файла переводов.
+func (t *TranslationEntry) comments() (list []string) {
+	list = append(list, translationKeyComment+t.Key)
+
+	if t.Field != "" {
+		list = append(list, translationFieldComment+t.Field)
+	}
+
+	return
+}
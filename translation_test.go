@@ -0,0 +1,300 @@
+package errors
+
+import (
+	"context"
+	"sm-errors/entities/details"
+	"sm-errors/entities/messages"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+// newTranslationCatalog - создание каталога для тестов переводов.
+func newTranslationCatalog(t *testing.T) (c *Catalog) {
+	c = NewCatalog()
+
+	var err = c.Register(
+		Constructor[Error]{
+			ID:     "U-000001",
+			Type:   types.TypeNotFound,
+			Status: types.StatusError,
+
+			Message: new(messages.TemplateMessage).
+				Key("U-000001").
+				Template("User {user_id} not found"),
+		},
+		Constructor[Error]{
+			ID:     "V-000001",
+			Type:   types.TypeValidation,
+			Status: types.StatusError,
+
+			Message: new(messages.PluralMessage).
+				Locale("en").
+				Form(messages.PluralOne, "{count} field is invalid").
+				Form(messages.PluralOther, "{count} fields are invalid"),
+			Details: new(details.Details).
+				SetField(
+					new(details.FieldKey).Add("name"),
+					new(messages.TextMessage).Text("Field is required"),
+				).
+				SetField(
+					new(details.FieldKey).Add("email"),
+					new(messages.TextMessage).Text("Field is required"),
+				),
+		},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+const testPOT = `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;\n"
+
+#. key: U-000001
+msgctxt "U-000001"
+msgid "User {user_id} not found"
+msgstr ""
+
+#. key: V-000001
+msgctxt "V-000001"
+msgid "{count} field is invalid"
+msgid_plural "{count} fields are invalid"
+msgstr[0] ""
+msgstr[1] ""
+
+#. key: V-000001:name
+#. field: name
+#. key: V-000001:email
+#. field: email
+msgctxt "V-000001"
+msgid "Field is required"
+msgstr ""
+`
+
+func TestCatalog_ExportPO(t *testing.T) {
+	var c = newTranslationCatalog(t)
+
+	var f, r = c.ExportPO("", nil)
+
+	if got := string(f.Bytes()); got != testPOT {
+		t.Errorf("ExportPO() =\n%s\nwant\n%s", got, testPOT)
+	}
+
+	if len(r.Untranslated) != 0 || len(r.Stale) != 0 {
+		t.Errorf("ExportPO() report = %v", r)
+	}
+
+	var existing, err = messages.ParsePO("ru.po", strings.NewReader(`msgid ""
+msgstr "Language: ru\n"
+
+msgctxt "U-000001"
+msgid "User {user_id} not found"
+msgstr "Пользователь {user_id} не найден"
+
+msgctxt "U-000002"
+msgid "User is blocked"
+msgstr "Пользователь заблокирован"
+`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, r = c.ExportPO("ru", existing)
+
+	if got := f.HeaderValue(messages.POHeaderLanguage); got != "ru" {
+		t.Errorf("ExportPO() Language = %v, want %v", got, "ru")
+	}
+
+	if e, ok := f.Lookup("V-000001", "{count} field is invalid"); !ok || len(e.Str) != 3 {
+		t.Errorf("ExportPO() plural entry = %v, %v, want 3 forms", e, ok)
+	}
+
+	var want = "ru: 2 untranslated, 1 stale" +
+		"\n  untranslated V-000001: \"{count} field is invalid\"" +
+		"\n  untranslated V-000001: \"Field is required\"" +
+		"\n  stale U-000002: \"User is blocked\""
+
+	if got := r.String(); got != want {
+		t.Errorf("ExportPO() report =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := f.Entries[len(f.Entries)-1]; !got.Obsolete || got.ID != "User is blocked" {
+		t.Errorf("ExportPO() stale entry = %+v", got)
+	}
+}
+
+func TestCatalog_ImportPO(t *testing.T) {
+	var c = newTranslationCatalog(t)
+
+	var f, err = messages.ParsePO("ru.po", strings.NewReader(`msgid ""
+msgstr "Language: ru\n"
+
+msgctxt "U-000001"
+msgid "User {user_id} not found"
+msgstr "Пользователь {user_id} не найден"
+
+msgctxt "V-000001"
+msgid "{count} field is invalid"
+msgid_plural "{count} fields are invalid"
+msgstr[0] "{count} поле заполнено неверно"
+msgstr[1] "{count} поля заполнены неверно"
+msgstr[2] "{count} полей заполнено неверно"
+
+#, fuzzy
+msgctxt "V-000001"
+msgid "Field is required"
+msgstr "Поле обязательно"
+
+msgctxt "U-000002"
+msgid "User is blocked"
+msgstr "Пользователь заблокирован"
+`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		b = messages.NewBundle("en")
+		r *TranslationReport
+	)
+
+	if r, err = c.ImportPO(b, f); err != nil {
+		t.Fatal(err)
+	}
+
+	var want = "ru: 1 untranslated, 1 stale" +
+		"\n  untranslated V-000001: \"Field is required\"" +
+		"\n  stale U-000002: \"User is blocked\""
+
+	if got := r.String(); got != want {
+		t.Errorf("ImportPO() report =\n%s\nwant\n%s", got, want)
+	}
+
+	var wantKeys = []string{"U-000001", "V-000001#few", "V-000001#many", "V-000001#one", "V-000001#other"}
+
+	if got := b.Keys("ru"); strings.Join(got, ",") != strings.Join(wantKeys, ",") {
+		t.Errorf("ImportPO() keys = %v, want %v", got, wantKeys)
+	}
+
+	if _, err = c.ImportPO(b, new(messages.POFile)); err == nil {
+		t.Errorf("ImportPO() without language error = nil")
+	}
+
+	f.SetHeader(messages.POHeaderLanguage, "en")
+
+	if _, err = c.ImportPO(b, f); err == nil {
+		t.Errorf("ImportPO() with wrong plural forms error = nil")
+	}
+}
+
+func TestCatalog_Localize(t *testing.T) {
+	var c = newTranslationCatalog(t)
+
+	var f, err = messages.ParsePO("ru.po", strings.NewReader(`msgid ""
+msgstr "Language: ru\n"
+
+msgctxt "U-000001"
+msgid "User {user_id} not found"
+msgstr "Пользователь {user_id} не найден"
+
+msgctxt "V-000001"
+msgid "{count} field is invalid"
+msgid_plural "{count} fields are invalid"
+msgstr[0] "{count} поле заполнено неверно"
+msgstr[1] "{count} поля заполнены неверно"
+msgstr[2] "{count} полей заполнено неверно"
+
+msgctxt "V-000001"
+msgid "Field is required"
+msgstr "Поле обязательно"
+`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b = messages.NewBundle("en")
+
+	if _, err = c.ImportPO(b, f); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Localize(b)
+
+	tests := []struct {
+		name        string
+		id          types.ID
+		args        map[string]any
+		locale      string
+		wantMessage string
+		wantField   string
+	}{
+		{
+			name:        "Case 1",
+			id:          "U-000001",
+			args:        map[string]any{"user_id": 7},
+			locale:      "ru",
+			wantMessage: "Пользователь 7 не найден",
+		},
+		{
+			name:        "Case 2",
+			id:          "U-000001",
+			args:        map[string]any{"user_id": 7},
+			locale:      "en",
+			wantMessage: "User 7 not found",
+		},
+		{
+			name:        "Case 3",
+			id:          "V-000001",
+			args:        map[string]any{messages.PluralCountArg: 5},
+			locale:      "ru",
+			wantMessage: "5 полей заполнено неверно",
+			wantField:   "Поле обязательно",
+		},
+		{
+			name:        "Case 4",
+			id:          "V-000001",
+			args:        map[string]any{messages.PluralCountArg: 1},
+			locale:      "en",
+			wantMessage: "1 field is invalid",
+			wantField:   "Field is required",
+		},
+		{
+			name:        "Case 5",
+			id:          "V-000001",
+			args:        map[string]any{messages.PluralCountArg: 1.5},
+			locale:      "ru",
+			wantMessage: "1.5 поля заполнены неверно",
+			wantField:   "Поле обязательно",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e = MustGetFrom[Error](c, tt.id)()
+
+			e.SetMessageArgs(tt.args)
+			e.WithContext(messages.WithLocales(context.Background(), tt.locale))
+
+			if got := e.Message(); got != tt.wantMessage {
+				t.Errorf("Message() = %v, want %v", got, tt.wantMessage)
+			}
+
+			if tt.wantField == "" {
+				return
+			}
+
+			if got := e.Details().PeekFieldMessage("email").String(); got != tt.wantField {
+				t.Errorf("PeekFieldMessage() = %v, want %v", got, tt.wantField)
+			}
+		})
+	}
+}
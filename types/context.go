@@ -0,0 +1,9 @@
+package types
+
+import "context"
+
+type (
+	// ContextExtractor - функция для переноса данных из контекста в детали ошибки.
+	// Вызывается при установке контекста ошибки через WithContext и при упаковке ошибки.
+	ContextExtractor func(ctx context.Context, d Details)
+)
@@ -8,8 +8,11 @@ type (
 		Reset() Details
 
 		PeekFieldMessage(k string) (m DetailsFieldMessage)
+		PeekFieldViolations(k string) (violations []DetailsFieldViolation)
+		Fields() (fields []DetailsField)
 		SetField(k DetailsFieldKey, m DetailsFieldMessage) Details
 		SetFields(fields ...DetailsField) Details
+		AddFieldViolation(k DetailsFieldKey, v DetailsFieldViolation) Details
 		ResetFields() Details
 
 		Clone() Details
@@ -26,13 +29,25 @@ type (
 		AddArray(name string, index int) DetailsFieldKey
 		AddMap(name string, key any) DetailsFieldKey
 		String() (str string)
+		JSONPointer() (str string)
+		JSONPath() (str string)
 
 		Clone() DetailsFieldKey
 	}
 
 	// DetailsField - поле c сообщением об ошибке.
+	// Message - основное сообщение поля, Violations - все нарушения правил поля.
 	DetailsField struct {
-		Key     DetailsFieldKey
+		Key        DetailsFieldKey
+		Message    DetailsFieldMessage
+		Violations []DetailsFieldViolation
+	}
+
+	// DetailsFieldViolation - нарушение правила поля.
+	// Code - машиночитаемый код правила (например, "min_length"), Params - параметры правила.
+	DetailsFieldViolation struct {
+		Code    string
+		Params  map[string]any
 		Message DetailsFieldMessage
 	}
 )
@@ -6,4 +6,35 @@ type (
 		String() (str string)
 		Clone() Message
 	}
+
+	// LocalizedMessage - описание сообщения ошибки с поддержкой нескольких языков.
+	LocalizedMessage interface {
+		Message
+
+		SetLocales(locales ...string)
+		Localize(locales ...string) (str string)
+	}
+
+	// KindMessage - описание сообщения ошибки с типом, по которому оно распаковывается
+	// при передаче между сервисами.
+	KindMessage interface {
+		Message
+
+		Kind() (kind string)
+	}
+
+	// ArgsMessage - описание сообщения ошибки с именованными аргументами.
+	ArgsMessage interface {
+		Message
+
+		SetArg(name string, v any)
+		Args() (args map[string]any)
+	}
+
+	// TemplateMessage - описание сообщения ошибки по шаблону с именованными параметрами.
+	TemplateMessage interface {
+		ArgsMessage
+
+		Validate() (err error)
+	}
 )
@@ -0,0 +1,69 @@
+package types
+
+import "runtime"
+
+const (
+	StackModeDefault StackMode = iota
+	StackModeOff
+	StackModeCaller
+	StackModeFull
+)
+
+var stackModesList = [...]string{
+	StackModeDefault: "default",
+	StackModeOff:     "off",
+	StackModeCaller:  "caller",
+	StackModeFull:    "full",
+}
+
+type (
+	// StackMode - режим захвата стека вызовов.
+	StackMode int
+
+	// StackTrace - стек вызовов ошибки.
+	StackTrace []uintptr
+)
+
+// String - получение строкового представления режима захвата стека вызовов.
+func (m StackMode) String() (str string) {
+	if m >= StackModeDefault && int(m) < len(stackModesList) {
+		return stackModesList[m]
+	}
+
+	return stackModesList[StackModeDefault]
+}
+
+// ParseStackMode - парсинг режима захвата стека вызовов из строки.
+func ParseStackMode(str string) (m StackMode) {
+	m = StackModeDefault
+
+	for i, m_ := range stackModesList {
+		if m_ == str {
+			m = StackMode(i)
+			break
+		}
+	}
+
+	return
+}
+
+// Frames - получение кадров стека вызовов.
+func (st StackTrace) Frames() (frames []runtime.Frame) {
+	if len(st) == 0 {
+		return
+	}
+
+	var iter = runtime.CallersFrames(st)
+
+	for {
+		var frame, more = iter.Next()
+
+		frames = append(frames, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	return
+}
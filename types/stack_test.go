@@ -0,0 +1,100 @@
+package types
+
+import "testing"
+
+func TestStackMode_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       StackMode
+		wantStr string
+	}{
+		{
+			name:    "Case 1",
+			m:       StackModeDefault,
+			wantStr: "default",
+		},
+		{
+			name:    "Case 2",
+			m:       StackModeOff,
+			wantStr: "off",
+		},
+		{
+			name:    "Case 3",
+			m:       StackModeCaller,
+			wantStr: "caller",
+		},
+		{
+			name:    "Case 4",
+			m:       StackModeFull,
+			wantStr: "full",
+		},
+		{
+			name:    "Case 5",
+			m:       -1,
+			wantStr: "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotStr := tt.m.String(); gotStr != tt.wantStr {
+				t.Errorf("String() = %v, want %v", gotStr, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestParseStackMode(t *testing.T) {
+	type args struct {
+		str string
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wantM StackMode
+	}{
+		{
+			name: "Case 1",
+			args: args{
+				str: "off",
+			},
+			wantM: StackModeOff,
+		},
+		{
+			name: "Case 2",
+			args: args{
+				str: "caller",
+			},
+			wantM: StackModeCaller,
+		},
+		{
+			name: "Case 3",
+			args: args{
+				str: "full",
+			},
+			wantM: StackModeFull,
+		},
+		{
+			name: "Case 4",
+			args: args{
+				str: "123",
+			},
+			wantM: StackModeDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotM := ParseStackMode(tt.args.str); gotM != tt.wantM {
+				t.Errorf("ParseStackMode() = %v, want %v", gotM, tt.wantM)
+			}
+		})
+	}
+}
+
+func TestStackTrace_Frames(t *testing.T) {
+	if frames := StackTrace(nil).Frames(); len(frames) != 0 {
+		t.Errorf("Frames() = %v, want empty", frames)
+	}
+}
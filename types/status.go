@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
 const (
 	StatusUnknown Status = iota
 	StatusFailed
@@ -7,31 +13,202 @@ const (
 	StatusFatal
 )
 
-var statusList = [...]string{
-	StatusUnknown: "unknown",
-	StatusFailed:  "failed",
-	StatusError:   "error",
-	StatusFatal:   "fatal",
+// Уровни серьезности syslog (RFC 5424).
+const (
+	SyslogEmergency = iota
+	SyslogAlert
+	SyslogCritical
+	SyslogError
+	SyslogWarning
+	SyslogNotice
+	SyslogInformational
+	SyslogDebug
+)
+
+// statuses - реестр статусов ошибок.
+var statuses = struct {
+	list  []StatusInfo
+	rwMux sync.RWMutex
+}{
+	list: []StatusInfo{
+		StatusUnknown: {
+			Name:           "unknown",
+			Severity:       0,
+			SlogLevel:      slog.LevelWarn,
+			SyslogSeverity: SyslogWarning,
+		},
+		StatusFailed: {
+			Name:           "failed",
+			Severity:       100,
+			SlogLevel:      slog.LevelWarn,
+			SyslogSeverity: SyslogWarning,
+		},
+		StatusError: {
+			Name:           "error",
+			Severity:       200,
+			SlogLevel:      slog.LevelError,
+			SyslogSeverity: SyslogError,
+		},
+		StatusFatal: {
+			Name:           "fatal",
+			Severity:       300,
+			SlogLevel:      slog.LevelError + 4,
+			SyslogSeverity: SyslogCritical,
+		},
+	},
+}
+
+type (
+	// Status - статус ошибки.
+	Status int
+
+	// StatusInfo - описание статуса ошибки.
+	// Severity задает порядок статусов, чем больше значение, тем серьезнее статус.
+	StatusInfo struct {
+		Name     string
+		Severity int
+
+		SlogLevel      slog.Level
+		SyslogSeverity int
+	}
+)
+
+// RegisterStatus - регистрация нового статуса ошибки.
+// Имя статуса должно быть непустым и уникальным.
+func RegisterStatus(info StatusInfo) (s Status, err error) {
+	if info.Name == "" {
+		err = fmt.Errorf("status name is empty")
+		return
+	}
+
+	if info.SyslogSeverity < SyslogEmergency || info.SyslogSeverity > SyslogDebug {
+		err = fmt.Errorf("status '%s' has invalid syslog severity %d", info.Name, info.SyslogSeverity)
+		return
+	}
+
+	statuses.rwMux.Lock()
+	defer statuses.rwMux.Unlock()
+
+	for _, registered := range statuses.list {
+		if registered.Name == info.Name {
+			err = fmt.Errorf("status '%s' is already registered", info.Name)
+			return
+		}
+	}
+
+	s = Status(len(statuses.list))
+	statuses.list = append(statuses.list, info)
+
+	return
+}
+
+// MustRegisterStatus - регистрация нового статуса ошибки с паникой в случае ошибки.
+func MustRegisterStatus(info StatusInfo) (s Status) {
+	var err error
+
+	if s, err = RegisterStatus(info); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// Statuses - получение списка зарегистрированных статусов ошибок.
+func Statuses() (list []Status) {
+	statuses.rwMux.RLock()
+	defer statuses.rwMux.RUnlock()
+
+	list = make([]Status, 0, len(statuses.list))
+
+	for i := range statuses.list {
+		list = append(list, Status(i))
+	}
+
+	return
+}
+
+// Info - получение описания статуса ошибки.
+func (s Status) Info() (info StatusInfo, ok bool) {
+	statuses.rwMux.RLock()
+	defer statuses.rwMux.RUnlock()
+
+	if s >= StatusUnknown && int(s) < len(statuses.list) {
+		return statuses.list[s], true
+	}
+
+	return
 }
 
-// Status - статус ошибки.
-type Status int
+// info - получение описания статуса ошибки, незарегистрированные статусы считаются неизвестными.
+func (s Status) info() (info StatusInfo) {
+	var ok bool
+
+	if info, ok = s.Info(); !ok {
+		info, _ = StatusUnknown.Info()
+	}
+
+	return
+}
 
 // String - получение строкового представления статуса ошибки.
 func (s Status) String() (str string) {
-	if s >= StatusUnknown && int(s) < len(statusList) {
-		return statusList[s]
+	return s.info().Name
+}
+
+// Severity - получение ранга серьезности статуса ошибки.
+func (s Status) Severity() (severity int) {
+	return s.info().Severity
+}
+
+// AtLeast - проверка, что статус ошибки не менее серьезен, чем указанный.
+func (s Status) AtLeast(o Status) (ok bool) {
+	return s.Severity() >= o.Severity()
+}
+
+// Max - получение самого серьезного статуса среди текущего и указанных.
+// При равной серьезности предпочтение отдается статусу, указанному раньше.
+func (s Status) Max(others ...Status) (m Status) {
+	m = s
+
+	for _, o := range others {
+		if o.Severity() > m.Severity() {
+			m = o
+		}
 	}
 
-	return statusList[StatusUnknown]
+	return
+}
+
+// SlogLevel - получение уровня логирования slog для статуса ошибки.
+func (s Status) SlogLevel() (l slog.Level) {
+	return s.info().SlogLevel
+}
+
+// SyslogSeverity - получение уровня серьезности syslog (RFC 5424) для статуса ошибки.
+func (s Status) SyslogSeverity() (severity int) {
+	return s.info().SyslogSeverity
+}
+
+// MarshalText - упаковать в текстовый формат.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText - распаковать из текстового формата.
+func (s *Status) UnmarshalText(text []byte) error {
+	*s = ParseStatus(string(text))
+	return nil
 }
 
 // ParseStatus - парсинг статуса ошибки из строки.
 func ParseStatus(str string) (s Status) {
 	s = StatusUnknown
 
-	for i, s_ := range statusList {
-		if s_ == str {
+	statuses.rwMux.RLock()
+	defer statuses.rwMux.RUnlock()
+
+	for i, info := range statuses.list {
+		if info.Name == str {
 			s = Status(i)
 			break
 		}
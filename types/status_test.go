@@ -1,6 +1,10 @@
 package types
 
-import "testing"
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
 
 func TestStatus_String(t *testing.T) {
 	tests := []struct {
@@ -111,3 +115,223 @@ func TestParseStatus(t *testing.T) {
 		})
 	}
 }
+
+// restoreStatuses - восстановление реестра статусов ошибок после теста.
+func restoreStatuses(t *testing.T) {
+	statuses.rwMux.RLock()
+	var n = len(statuses.list)
+	statuses.rwMux.RUnlock()
+
+	t.Cleanup(func() {
+		statuses.rwMux.Lock()
+		statuses.list = statuses.list[:n]
+		statuses.rwMux.Unlock()
+	})
+}
+
+func TestRegisterStatus(t *testing.T) {
+	restoreStatuses(t)
+
+	tests := []struct {
+		name    string
+		info    StatusInfo
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			info: StatusInfo{
+				Name:           "warning",
+				Severity:       50,
+				SlogLevel:      slog.LevelWarn,
+				SyslogSeverity: SyslogWarning,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			info: StatusInfo{
+				Name:           "critical",
+				Severity:       250,
+				SlogLevel:      slog.LevelError,
+				SyslogSeverity: SyslogCritical,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 3",
+			info: StatusInfo{
+				Name: "warning",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Case 4",
+			info:    StatusInfo{},
+			wantErr: true,
+		},
+		{
+			name: "Case 5",
+			info: StatusInfo{
+				Name:           "broken",
+				SyslogSeverity: 8,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotS, err := RegisterStatus(tt.info)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if gotS.String() != tt.info.Name {
+				t.Errorf("String() = %v, want %v", gotS.String(), tt.info.Name)
+			}
+
+			if parsed := ParseStatus(tt.info.Name); parsed != gotS {
+				t.Errorf("ParseStatus() = %v, want %v", parsed, gotS)
+			}
+
+			if gotS.Severity() != tt.info.Severity {
+				t.Errorf("Severity() = %v, want %v", gotS.Severity(), tt.info.Severity)
+			}
+
+			var data, _ = json.Marshal(gotS)
+
+			var decoded Status
+
+			if err = json.Unmarshal(data, &decoded); err != nil || decoded != gotS {
+				t.Errorf("json round trip = %v, %v, want %v", decoded, err, gotS)
+			}
+		})
+	}
+}
+
+func TestStatus_Compare(t *testing.T) {
+	restoreStatuses(t)
+
+	var (
+		warning = MustRegisterStatus(StatusInfo{
+			Name:           "warning",
+			Severity:       50,
+			SlogLevel:      slog.LevelWarn,
+			SyslogSeverity: SyslogWarning,
+		})
+		critical = MustRegisterStatus(StatusInfo{
+			Name:           "critical",
+			Severity:       250,
+			SlogLevel:      slog.LevelError,
+			SyslogSeverity: SyslogCritical,
+		})
+	)
+
+	tests := []struct {
+		name        string
+		s           Status
+		others      []Status
+		wantMax     Status
+		wantAtLeast bool
+	}{
+		{
+			name:        "Case 1",
+			s:           StatusFailed,
+			others:      []Status{StatusError},
+			wantMax:     StatusError,
+			wantAtLeast: false,
+		},
+		{
+			name:        "Case 2",
+			s:           critical,
+			others:      []Status{StatusError},
+			wantMax:     critical,
+			wantAtLeast: true,
+		},
+		{
+			name:        "Case 3",
+			s:           warning,
+			others:      []Status{StatusUnknown},
+			wantMax:     warning,
+			wantAtLeast: true,
+		},
+		{
+			name:        "Case 4",
+			s:           StatusError,
+			others:      []Status{critical, StatusFatal, warning},
+			wantMax:     StatusFatal,
+			wantAtLeast: false,
+		},
+		{
+			name:        "Case 5",
+			s:           -1,
+			others:      []Status{StatusUnknown},
+			wantMax:     -1,
+			wantAtLeast: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotMax := tt.s.Max(tt.others...); gotMax != tt.wantMax {
+				t.Errorf("Max() = %v, want %v", gotMax, tt.wantMax)
+			}
+
+			if gotAtLeast := tt.s.AtLeast(tt.others[0]); gotAtLeast != tt.wantAtLeast {
+				t.Errorf("AtLeast() = %v, want %v", gotAtLeast, tt.wantAtLeast)
+			}
+		})
+	}
+}
+
+func TestStatus_Levels(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          Status
+		wantSlog   slog.Level
+		wantSyslog int
+	}{
+		{
+			name:       "Case 1",
+			s:          StatusFailed,
+			wantSlog:   slog.LevelWarn,
+			wantSyslog: SyslogWarning,
+		},
+		{
+			name:       "Case 2",
+			s:          StatusError,
+			wantSlog:   slog.LevelError,
+			wantSyslog: SyslogError,
+		},
+		{
+			name:       "Case 3",
+			s:          StatusFatal,
+			wantSlog:   slog.LevelError + 4,
+			wantSyslog: SyslogCritical,
+		},
+		{
+			name:       "Case 4",
+			s:          100,
+			wantSlog:   slog.LevelWarn,
+			wantSyslog: SyslogWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.SlogLevel(); got != tt.wantSlog {
+				t.Errorf("SlogLevel() = %v, want %v", got, tt.wantSlog)
+			}
+
+			if got := tt.s.SyslogSeverity(); got != tt.wantSyslog {
+				t.Errorf("SyslogSeverity() = %v, want %v", got, tt.wantSyslog)
+			}
+		})
+	}
+}
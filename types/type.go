@@ -1,35 +1,199 @@
 package types
 
+import (
+	"fmt"
+	"sync"
+)
+
 const (
 	TypeUnknown ErrorType = iota
 	TypeSystem
+	TypeValidation
+	TypeAuthentication
+	TypeAuthorization
+	TypeNotFound
+	TypeConflict
+	TypeRateLimit
+	TypeExternal
 )
 
-var errorTypesList = [...]string{
-	TypeUnknown: "unknown",
-	TypeSystem:  "system",
+// errorTypes - реестр типов ошибок.
+var errorTypes = struct {
+	list  []ErrorTypeInfo
+	rwMux sync.RWMutex
+}{
+	list: []ErrorTypeInfo{
+		TypeUnknown: {
+			Name:                "unknown",
+			Description:         "Неизвестная ошибка. ",
+			RestAPIStatusCode:   500,
+			WebSocketStatusCode: 1011,
+			GrpcStatusCode:      2,
+		},
+		TypeSystem: {
+			Name:                "system",
+			Description:         "Системная ошибка. ",
+			RestAPIStatusCode:   500,
+			WebSocketStatusCode: 1011,
+			GrpcStatusCode:      13,
+		},
+		TypeValidation: {
+			Name:                "validation",
+			Description:         "Ошибка валидации входных данных. ",
+			RestAPIStatusCode:   400,
+			WebSocketStatusCode: 4400,
+			GrpcStatusCode:      3,
+		},
+		TypeAuthentication: {
+			Name:                "authentication",
+			Description:         "Ошибка аутентификации. ",
+			RestAPIStatusCode:   401,
+			WebSocketStatusCode: 4401,
+			GrpcStatusCode:      16,
+		},
+		TypeAuthorization: {
+			Name:                "authorization",
+			Description:         "Недостаточно прав доступа. ",
+			RestAPIStatusCode:   403,
+			WebSocketStatusCode: 4403,
+			GrpcStatusCode:      7,
+		},
+		TypeNotFound: {
+			Name:                "not_found",
+			Description:         "Ресурс не найден. ",
+			RestAPIStatusCode:   404,
+			WebSocketStatusCode: 4404,
+			GrpcStatusCode:      5,
+		},
+		TypeConflict: {
+			Name:                "conflict",
+			Description:         "Конфликт состояния ресурса. ",
+			RestAPIStatusCode:   409,
+			WebSocketStatusCode: 4409,
+			GrpcStatusCode:      6,
+		},
+		TypeRateLimit: {
+			Name:                "rate_limit",
+			Description:         "Превышен лимит запросов. ",
+			RestAPIStatusCode:   429,
+			WebSocketStatusCode: 4429,
+			GrpcStatusCode:      8,
+		},
+		TypeExternal: {
+			Name:                "external",
+			Description:         "Ошибка внешней зависимости. ",
+			RestAPIStatusCode:   502,
+			WebSocketStatusCode: 1014,
+			GrpcStatusCode:      14,
+		},
+	},
 }
 
 type (
 	// ErrorType - тип ошибки.
 	ErrorType int
+
+	// ErrorTypeInfo - описание типа ошибки.
+	ErrorTypeInfo struct {
+		Name        string
+		Description string
+
+		RestAPIStatusCode   int
+		WebSocketStatusCode int
+		GrpcStatusCode      int
+	}
 )
 
+// RegisterErrorType - регистрация нового типа ошибки.
+// Имя типа должно быть непустым и уникальным.
+func RegisterErrorType(info ErrorTypeInfo) (t ErrorType, err error) {
+	if info.Name == "" {
+		err = fmt.Errorf("error type name is empty")
+		return
+	}
+
+	errorTypes.rwMux.Lock()
+	defer errorTypes.rwMux.Unlock()
+
+	for _, registered := range errorTypes.list {
+		if registered.Name == info.Name {
+			err = fmt.Errorf("error type '%s' is already registered", info.Name)
+			return
+		}
+	}
+
+	t = ErrorType(len(errorTypes.list))
+	errorTypes.list = append(errorTypes.list, info)
+
+	return
+}
+
+// MustRegisterErrorType - регистрация нового типа ошибки с паникой в случае ошибки.
+func MustRegisterErrorType(info ErrorTypeInfo) (t ErrorType) {
+	var err error
+
+	if t, err = RegisterErrorType(info); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// ErrorTypes - получение списка зарегистрированных типов ошибок.
+func ErrorTypes() (list []ErrorType) {
+	errorTypes.rwMux.RLock()
+	defer errorTypes.rwMux.RUnlock()
+
+	list = make([]ErrorType, 0, len(errorTypes.list))
+
+	for i := range errorTypes.list {
+		list = append(list, ErrorType(i))
+	}
+
+	return
+}
+
+// Info - получение описания типа ошибки.
+func (t ErrorType) Info() (info ErrorTypeInfo, ok bool) {
+	errorTypes.rwMux.RLock()
+	defer errorTypes.rwMux.RUnlock()
+
+	if t >= TypeUnknown && int(t) < len(errorTypes.list) {
+		return errorTypes.list[t], true
+	}
+
+	return
+}
+
 // String - получение строкового представления типа ошибки.
 func (t ErrorType) String() (str string) {
-	if t >= TypeUnknown && int(t) < len(statusList) {
-		return errorTypesList[t]
+	if info, ok := t.Info(); ok {
+		return info.Name
 	}
 
-	return errorTypesList[TypeUnknown]
+	return TypeUnknown.String()
+}
+
+// MarshalText - упаковать в текстовый формат.
+func (t ErrorType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText - распаковать из текстового формата.
+func (t *ErrorType) UnmarshalText(text []byte) error {
+	*t = ParseErrorType(string(text))
+	return nil
 }
 
 // ParseErrorType - парсинг типа ошибки из строки.
 func ParseErrorType(str string) (t ErrorType) {
 	t = TypeUnknown
 
-	for i, t_ := range errorTypesList {
-		if t_ == str {
+	errorTypes.rwMux.RLock()
+	defer errorTypes.rwMux.RUnlock()
+
+	for i, info := range errorTypes.list {
+		if info.Name == str {
 			t = ErrorType(i)
 			break
 		}
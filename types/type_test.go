@@ -1,6 +1,10 @@
 package types
 
-import "testing"
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
 
 func TestErrorType_String(t *testing.T) {
 	tests := []struct {
@@ -23,6 +27,16 @@ func TestErrorType_String(t *testing.T) {
 			t:       -1,
 			wantStr: "unknown",
 		},
+		{
+			name:    "Case 4",
+			t:       TypeNotFound,
+			wantStr: "not_found",
+		},
+		{
+			name:    "Case 5",
+			t:       1000,
+			wantStr: "unknown",
+		},
 	}
 
 	for _, tt := range tests {
@@ -72,6 +86,13 @@ func TestParseErrorType(t *testing.T) {
 			},
 			wantT: TypeUnknown,
 		},
+		{
+			name: "Case 5",
+			args: args{
+				str: "rate_limit",
+			},
+			wantT: TypeRateLimit,
+		},
 	}
 
 	for _, tt := range tests {
@@ -82,3 +103,144 @@ func TestParseErrorType(t *testing.T) {
 		})
 	}
 }
+
+// restoreErrorTypes - восстановление реестра типов ошибок после теста.
+func restoreErrorTypes(t *testing.T) {
+	errorTypes.rwMux.RLock()
+	var n = len(errorTypes.list)
+	errorTypes.rwMux.RUnlock()
+
+	t.Cleanup(func() {
+		errorTypes.rwMux.Lock()
+		errorTypes.list = errorTypes.list[:n]
+		errorTypes.rwMux.Unlock()
+	})
+}
+
+func TestRegisterErrorType(t *testing.T) {
+	restoreErrorTypes(t)
+
+	tests := []struct {
+		name    string
+		info    ErrorTypeInfo
+		wantErr bool
+	}{
+		{
+			name: "Case 1",
+			info: ErrorTypeInfo{
+				Name:              "test_payment_required",
+				Description:       "Требуется оплата. ",
+				RestAPIStatusCode: 402,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case 2",
+			info: ErrorTypeInfo{
+				Name: "test_payment_required",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 3",
+			info: ErrorTypeInfo{
+				Name: "system",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Case 4",
+			info:    ErrorTypeInfo{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, err := RegisterErrorType(tt.info)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterErrorType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if gotT.String() != tt.info.Name {
+				t.Errorf("String() = %v, want %v", gotT.String(), tt.info.Name)
+			}
+
+			if parsed := ParseErrorType(tt.info.Name); parsed != gotT {
+				t.Errorf("ParseErrorType() = %v, want %v", parsed, gotT)
+			}
+
+			if info, ok := gotT.Info(); !ok || info != tt.info {
+				t.Errorf("Info() = %v, %v, want %v", info, ok, tt.info)
+			}
+		})
+	}
+}
+
+func TestErrorType_MarshalText(t *testing.T) {
+	type wrapper struct {
+		Type ErrorType `json:"type" xml:"type,attr"`
+	}
+
+	restoreErrorTypes(t)
+
+	var custom = MustRegisterErrorType(ErrorTypeInfo{
+		Name: "test_teapot",
+	})
+
+	tests := []struct {
+		name     string
+		t        ErrorType
+		wantJSON string
+		wantXML  string
+	}{
+		{
+			name:     "Case 1",
+			t:        TypeValidation,
+			wantJSON: `{"type":"validation"}`,
+			wantXML:  `<wrapper type="validation"></wrapper>`,
+		},
+		{
+			name:     "Case 2",
+			t:        custom,
+			wantJSON: `{"type":"test_teapot"}`,
+			wantXML:  `<wrapper type="test_teapot"></wrapper>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				jsonGot, _ = json.Marshal(wrapper{Type: tt.t})
+				xmlGot, _  = xml.Marshal(wrapper{Type: tt.t})
+			)
+
+			if string(jsonGot) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", jsonGot, tt.wantJSON)
+			}
+
+			if string(xmlGot) != tt.wantXML {
+				t.Errorf("xml.Marshal() = %s, want %s", xmlGot, tt.wantXML)
+			}
+
+			var (
+				fromJSON wrapper
+				fromXML  wrapper
+			)
+
+			if err := json.Unmarshal(jsonGot, &fromJSON); err != nil || fromJSON.Type != tt.t {
+				t.Errorf("json.Unmarshal() = %v, %v, want %v", fromJSON.Type, err, tt.t)
+			}
+
+			if err := xml.Unmarshal(xmlGot, &fromXML); err != nil || fromXML.Type != tt.t {
+				t.Errorf("xml.Unmarshal() = %v, %v, want %v", fromXML.Type, err, tt.t)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"net/http"
+	"sm-errors/entities/messages"
+	"sm-errors/entities/validation"
+	"sm-errors/types"
+)
+
+// ValidationID - идентификатор ошибок валидации по умолчанию.
+const ValidationID types.ID = "VALIDATION"
+
+// Строители ошибок валидации по умолчанию.
+var (
+	// ValidationError - строитель базовой ошибки валидации.
+	ValidationError = Constructor[Error]{
+		ID:     ValidationID,
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).Text("Validation failed. "),
+	}.Build()
+
+	// ValidationRestAPI - строитель rest api ошибки валидации с кодом ответа 400.
+	ValidationRestAPI = Constructor[RestAPI]{
+		ID:     ValidationID,
+		Type:   types.TypeValidation,
+		Status: types.StatusError,
+
+		Message: new(messages.TextMessage).Text("Validation failed. "),
+	}.RestAPI(RestAPIConstructor{
+		StatusCode: http.StatusBadRequest,
+	}).Build()
+)
+
+// Validate - проверка значения по правилам из тегов validate, см. validation.Validate.
+// Нарушения записываются в поля деталей ошибки, построенной строителем fn.
+// Если нарушений нет, возвращается nil. Некорректные правила в тегах вызывают панику.
+//
+//	if e := errors.Validate(errors.ValidationRestAPI, req); e != nil {
+//		return e
+//	}
+func Validate[T Error](fn Builder[T], v any) (e T) {
+	var fields, err = validation.Validate(v)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	e = fn()
+	e.Details().SetFields(fields...)
+
+	return
+}
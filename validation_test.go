@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"encoding/json"
+	"sm-errors/types"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	type request struct {
+		Email    string `json:"email"    validate:"required,email"`
+		Password string `json:"password" validate:"min=8,pattern=[0-9]"`
+	}
+
+	if e := Validate(ValidationRestAPI, &request{Email: "user@example.com", Password: "secret123"}); e != nil {
+		t.Fatalf("Validate() = %v, want nil", e)
+	}
+
+	var e = Validate(ValidationRestAPI, &request{Password: "short"})
+
+	if e == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+
+	if e.StatusCode() != 400 || e.Type() != types.TypeValidation || e.ID() != ValidationID {
+		t.Errorf("Validate() = %v (%d), want validation error with status code 400", e, e.StatusCode())
+	}
+
+	if got := len(e.Details().PeekFieldViolations("password")); got != 2 {
+		t.Errorf("Validate() password violations = %d, want 2", got)
+	}
+
+	if got := e.Details().PeekFieldMessage("email"); got == nil || got.String() != "Value is required" {
+		t.Errorf("Validate() email message = %v, want %v", got, "Value is required")
+	}
+
+	var data, err = json.Marshal(e)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `"violations":{`; !strings.Contains(string(data), want) {
+		t.Errorf("MarshalJSON() = %s, want to contain %s", data, want)
+	}
+
+	// Ошибки строятся независимо друг от друга.
+	if e := ValidationError(); len(e.Details().Fields()) != 0 {
+		t.Errorf("ValidationError() fields = %v, want none", e.Details().Fields())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Validate() did not panic on invalid tag")
+		}
+	}()
+
+	Validate(ValidationError, struct {
+		Name string `validate:"unknown"`
+	}{})
+}